@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+	"github.com/spf13/cobra"
+)
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle path/to/spec path/to/bundle",
+	Short: "Export the suite as a self-contained bundle.",
+	Long: `Writes the hydrated tests, specifications, implementations and assertion
+configuration of the suite to a single file. Suites with validation errors
+aren't bundled.
+
+Bundles with a .json extension are written as JSON, all others as binary protocol
+buffers. Bundles and .zip archives of suites can be used in place of a suite directory
+by run and score.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		suite, err := storage.LoadSuite(args[0])
+		if err != nil {
+			return err
+		}
+
+		var vs validation.ValidationSummary
+		suite.RunValidation(func(name string, v *validation.Validator) {
+			for _, result := range v.Results {
+				if result.Level == validation.LevelError {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", name, result.String())
+				}
+			}
+			vs.Update(v)
+		})
+		if vs.ErrorCount > 0 {
+			return fmt.Errorf("%d errors encountered, run check for details", vs.ErrorCount)
+		}
+
+		bundle := suite.Bundle()
+		if err := executor.WriteBundle(args[1], bundle); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"Bundled %d specs, %d implementations, %d tests into %s\n",
+			len(bundle.Specifications),
+			len(bundle.Implementations),
+			len(bundle.Tests),
+			args[1],
+		)
+
+		return nil
+	},
+}
+
+// loadTestSuite loads a suite directory, a .zip archive of one or a bundle file.
+func loadTestSuite(path string) (executor.TestSuite, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() || filepath.Ext(path) == ".zip" {
+		return storage.LoadSuite(path)
+	}
+
+	return executor.ReadBundle(path)
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+}
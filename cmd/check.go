@@ -2,53 +2,111 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 
+	"github.com/josephlewis42/scheme-compliance/tester/lint"
 	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
 	"github.com/josephlewis42/scheme-compliance/tester/validation"
 	"github.com/spf13/cobra"
 )
 
+var (
+	checkFormat    string
+	checkListRules bool
+)
+
 // checkCmd represents the check command
 var checkCmd = &cobra.Command{
 	Use:   "check path/to/spec",
 	Short: "Check the specification for errors.",
-	Long:  `Performs tests for the specification at the given path.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Performs tests for the specification at the given path.
+
+Results can be written as text, json, sarif or github workflow annotations.
+
+Lint rules are enabled in the suite's spec.lint.rules, use --list-rules to list them.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if checkListRules {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		cmd.SilenceUsage = true
 
+		if checkListRules {
+			for _, rule := range lint.Rules() {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", rule.Name, rule.Description)
+			}
+			return nil
+		}
+
+		var write func(io.Writer, []validation.Result) error
+		switch checkFormat {
+		case "text":
+		case "json":
+			write = validation.WriteJSON
+		case "sarif":
+			write = func(w io.Writer, results []validation.Result) error {
+				return validation.WriteSARIF(w, "spec-tester", results)
+			}
+		case "github":
+			write = validation.WriteGitHub
+		default:
+			return fmt.Errorf("unknown format %q, must be one of: text, json, sarif, github", checkFormat)
+		}
+
 		suite, err := storage.LoadSuite(args[0])
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprintf(
-			cmd.OutOrStdout(),
-			"Loaded %d specs, %d implementations, %d tests\n",
-			len(suite.Specifications),
-			len(suite.Implementations),
-			len(suite.Tests),
-		)
+		if write == nil {
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"Loaded %d specs, %d implementations, %d tests\n",
+				len(suite.Specifications),
+				len(suite.Implementations),
+				len(suite.Tests),
+			)
+			for _, imp := range suite.Imports {
+				fmt.Fprintf(
+					cmd.OutOrStdout(),
+					"Imported %d specs, %d tests from %s as %q\n",
+					len(imp.Specifications),
+					len(imp.Tests),
+					imp.Path,
+					imp.Name,
+				)
+			}
+		}
 
 		var vs validation.ValidationSummary
+		var results []validation.Result
 		suite.RunValidation(func(name string, v *validation.Validator) {
-			fmt.Fprintf(cmd.OutOrStdout(), "Checking: %s\n", name)
+			if write == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "Checking: %s\n", name)
 
-			for _, result := range v.Results {
-				fmt.Fprintln(cmd.OutOrStdout(), "-", result.String())
+				for _, result := range v.Results {
+					fmt.Fprintln(cmd.OutOrStdout(), "-", result.String())
+				}
 			}
 
+			results = append(results, v.Results...)
 			vs.Update(v)
 		})
 
-		fmt.Fprintf(
-			cmd.OutOrStdout(),
-			"\nResults: %d Errors, %d Warnings, %d Infos\n",
-			vs.ErrorCount,
-			vs.WarningCount,
-			vs.InfoCount,
-		)
+		if write == nil {
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"\nResults: %d Errors, %d Warnings, %d Infos\n",
+				vs.ErrorCount,
+				vs.WarningCount,
+				vs.InfoCount,
+			)
+		} else if err := write(cmd.OutOrStdout(), results); err != nil {
+			return err
+		}
 
 		if vs.ErrorCount > 0 {
 			return fmt.Errorf("%d errors encountered", vs.ErrorCount)
@@ -61,13 +119,6 @@ var checkCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(checkCmd)
 
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// checkCmd.PersistentFlags().String("foo", "", "A help for foo")
-
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// checkCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	checkCmd.Flags().StringVar(&checkFormat, "format", "text", "Output format: text, json, sarif or github")
+	checkCmd.Flags().BoolVar(&checkListRules, "list-rules", false, "List the available lint rules")
 }
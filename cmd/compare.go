@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/josephlewis42/scheme-compliance/tester/compare"
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	"github.com/spf13/cobra"
+)
+
+var compareFormat string
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:   "compare path/to/results-a.json path/to/results-b.json",
+	Short: "Compare two result sets and report regressions.",
+	Long: `Joins the stored results by implementation variant and test UID and reports
+which tests are newly passing, newly failing, still failing, added or removed.
+Tests that don't fail but whose status differs, like passing tests that are now
+skipped or expected failures, are reported as status-changed.
+
+Exits with an error if any tests are newly failing or added and failing.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		before, err := executor.ReadResultSet(args[0])
+		if err != nil {
+			return err
+		}
+
+		after, err := executor.ReadResultSet(args[1])
+		if err != nil {
+			return err
+		}
+
+		comparison := compare.Compare(before, after)
+
+		switch compareFormat {
+		case "text":
+			comparison.WriteText(cmd.OutOrStdout())
+
+		case "markdown":
+			comparison.WriteMarkdown(cmd.OutOrStdout())
+
+		case "json":
+			out, err := json.MarshalIndent(comparison, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		default:
+			return fmt.Errorf("unknown format %q, must be one of: text, json, markdown", compareFormat)
+		}
+
+		if regressions := comparison.Regressions(); regressions > 0 {
+			return fmt.Errorf("%d regressions encountered", regressions)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().StringVar(&compareFormat, "format", "text", "Output format, one of: text, json, markdown")
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/importer"
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importSuitePath          string
+	importName               string
+	importFormat             string
+	importInputExtension     string
+	importExpectedExtension  string
+	importExpectationType    string
+	importPrefixExpectations []string
+	importPrefixLabel        string
+	importDirectoryLabels    []string
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import path/to/corpus",
+	Short: "Import a corpus of test files as a test.",
+	Long: `Converts a directory of test files into a test in the suite.
+
+Two corpus formats are supported:
+
+  pairs   Input files with expected output files sharing their base name,
+          e.g. foo.scm and foo.out. The expected output is passed as a string
+          to the assertion named by --expect-type.
+
+  prefix  Input files whose expected outcome is a filename prefix, e.g. the
+          y_, n_ and i_ files of JSONTestSuite. Each prefix is mapped to an
+          expectation with --prefix-expect, e.g. --prefix-expect 'y={"success":{}}'.
+
+Each directory becomes a nested context, directory names can be mapped to labels
+with --dir-label.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		suite, err := storage.LoadSuite(importSuitePath)
+		if err != nil {
+			return err
+		}
+
+		opts := importer.Options{
+			Name:               importName,
+			Format:             importer.Format(importFormat),
+			InputExtension:     importInputExtension,
+			ExpectedExtension:  importExpectedExtension,
+			ExpectationType:    importExpectationType,
+			PrefixExpectations: make(map[string]v1.TestExpectation),
+			PrefixLabel:        importPrefixLabel,
+			DirectoryLabels:    importDirectoryLabels,
+		}
+
+		if opts.Name == "" {
+			opts.Name = v1.NameFrom(filepath.Base(filepath.Clean(args[0])))
+		}
+
+		for _, mapping := range importPrefixExpectations {
+			prefix, rawExpectation, found := strings.Cut(mapping, "=")
+			if !found {
+				return fmt.Errorf("--prefix-expect must be in the form prefix=json, got: %s", mapping)
+			}
+
+			var expectation v1.TestExpectation
+			if err := json.Unmarshal([]byte(rawExpectation), &expectation); err != nil {
+				return fmt.Errorf("invalid expectation for prefix %q: %w", prefix, err)
+			}
+			opts.PrefixExpectations[prefix] = expectation
+		}
+
+		result, err := importer.Import(args[0], opts)
+		if err != nil {
+			return err
+		}
+
+		for _, warning := range result.Warnings {
+			fmt.Fprintln(cmd.ErrOrStderr(), "warning:", warning)
+		}
+
+		file := storage.NewYamlFile[v1.Test](suite.TestPath(opts.Name))
+		file.Value = result.Test
+
+		return createFile(cmd, file)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importSuitePath, "suite", ".", "Path to the suite to import into")
+	importCmd.Flags().StringVar(&importName, "name", "", "Name of the generated test, defaults to the corpus directory name made into a valid name")
+	importCmd.Flags().StringVar(&importFormat, "format", string(importer.FormatPairs), "Corpus format, one of: pairs, prefix")
+	importCmd.Flags().StringVar(&importInputExtension, "input-ext", "", "Extension of input files, e.g. .scm")
+	importCmd.Flags().StringVar(&importExpectedExtension, "expected-ext", ".out", "Extension of expected output files for the pairs format")
+	importCmd.Flags().StringVar(&importExpectationType, "expect-type", "exact", "Assertion the expected output is passed to for the pairs format")
+	importCmd.Flags().StringArrayVar(&importPrefixExpectations, "prefix-expect", nil, "Expectation for a filename prefix as prefix=json for the prefix format")
+	importCmd.Flags().StringVar(&importPrefixLabel, "prefix-label", "", "Label key set to each file's prefix for the prefix format")
+	importCmd.Flags().StringSliceVar(&importDirectoryLabels, "dir-label", nil, "Label keys set to directory names, in order of depth")
+}
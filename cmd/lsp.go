@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/josephlewis42/scheme-compliance/tester/lsp"
+	"github.com/spf13/cobra"
+)
+
+// lspCmd represents the lsp command
+var lspCmd = &cobra.Command{
+	Use:   "lsp [path/to/spec]",
+	Short: "Run a language server for suite files.",
+	Long: `Runs a Language Server Protocol server over stdin and stdout.
+
+The server runs the same checks as check on the suite with the open documents
+in place of their files and publishes the results as diagnostics, documents
+outside the suite are validated on their own. It also completes label keys and
+values, expectation types and specification names, shows hydrated test cases
+on hover and resolves a variant's specifications to their definitions.
+
+If no path is given the workspace root supplied by the editor is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		root := ""
+		if len(args) > 0 {
+			root = args[0]
+		}
+
+		return lsp.NewServer(root).Serve(cmd.Context(), os.Stdin, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
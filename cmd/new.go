@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	"github.com/josephlewis42/scheme-compliance/tester/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newSuitePath       string
+	newSpecifications  []string
+	newExpectationType string
+	newExpectation     string
+)
+
+// newCmd represents the new command
+var newCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Create new suites, specifications, implementations and tests.",
+	Long:  `Generates starter files that pass check in the right place for the suite.`,
+}
+
+var newSuiteCmd = &cobra.Command{
+	Use:   "suite name",
+	Short: "Create a new suite in a directory with the given name.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		root := filepath.Join(newSuitePath, args[0])
+		for _, dir := range []string{"tests", "specifications", "implementations"} {
+			if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+				return err
+			}
+		}
+
+		return createFile(cmd, scaffold.NewSuite(root, args[0]))
+	},
+}
+
+var newSpecCmd = &cobra.Command{
+	Use:   "spec name",
+	Short: "Create a new specification in the suite.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		suite, err := storage.LoadSuite(newSuitePath)
+		if err != nil {
+			return err
+		}
+
+		return createFile(cmd, scaffold.NewSpecification(suite, args[0]))
+	},
+}
+
+var newImplementationCmd = &cobra.Command{
+	Use:   "implementation name",
+	Short: "Create a new implementation in the suite.",
+	Long:  `Creates an implementation targeting every specification in the suite unless --spec is supplied.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		suite, err := storage.LoadSuite(newSuitePath)
+		if err != nil {
+			return err
+		}
+
+		specifications := newSpecifications
+		if len(specifications) == 0 {
+			for _, spec := range suite.Specifications {
+				specifications = append(specifications, spec.Value.Metadata.Name)
+			}
+		}
+
+		if len(specifications) == 0 {
+			return errors.New("implementations must target a specification, create one first with: new spec")
+		}
+
+		return createFile(cmd, scaffold.NewImplementation(suite, args[0], specifications))
+	},
+}
+
+var newTestCmd = &cobra.Command{
+	Use:   "test name",
+	Short: "Create a new test in the suite.",
+	Long:  `Creates a test with a single case using the first assertion definition unless --expect-type is supplied.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		suite, err := storage.LoadSuite(newSuitePath)
+		if err != nil {
+			return err
+		}
+
+		expectationType := newExpectationType
+		if definitions := suite.TestSuite.Value.Spec.Assertions.Definitions; expectationType == "" && len(definitions) > 0 {
+			expectationType = definitions[0].Name
+		}
+
+		if expectationType == "" {
+			return errors.New("the suite has no assertion definitions, supply one with --expect-type")
+		}
+
+		if !json.Valid([]byte(newExpectation)) {
+			return fmt.Errorf("--expect must be valid JSON, got: %s", newExpectation)
+		}
+
+		return createFile(cmd, scaffold.NewTest(suite, args[0], expectationType, json.RawMessage(newExpectation)))
+	},
+}
+
+// createFile saves a new file without overwriting an existing one.
+func createFile[T any](cmd *cobra.Command, file *storage.YamlFile[T]) error {
+	if _, err := os.Stat(file.Path); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s already exists", file.Path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+		return err
+	}
+
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "created: %s\n", file.Path)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+
+	newCmd.PersistentFlags().StringVar(&newSuitePath, "suite", ".", "Path to the suite, or the parent directory for new suites")
+
+	newCmd.AddCommand(newSuiteCmd)
+	newCmd.AddCommand(newSpecCmd)
+
+	newCmd.AddCommand(newImplementationCmd)
+	newImplementationCmd.Flags().StringSliceVar(&newSpecifications, "spec", nil, "Names of the specifications the implementation targets")
+
+	newCmd.AddCommand(newTestCmd)
+	newTestCmd.Flags().StringVar(&newExpectationType, "expect-type", "", "Name of the assertion definition the case expects")
+	newTestCmd.Flags().StringVar(&newExpectation, "expect", `"TODO"`, "JSON options for the expectation")
+}
@@ -1,38 +1,132 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/josephlewis42/scheme-compliance/tester/executor"
-	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	runOutput  string
+	runRepeat  int
+	runRetries int
+
+	runImplementations []string
+	runVariants        []string
+	runSpecifications  []string
+	runSections        []string
+	runTests           []string
+	runSelectors       []string
 )
 
 // runCmd represents the run command
 var runCmd = &cobra.Command{
-	Use:   "run",
+	Use:   "run path/to/spec|path/to/bundle",
 	Short: "Run tests",
-	Long:  `Run tests for all specifications.`,
+	Long: `Run tests for all specifications.
+
+Filters may be repeated, a test runs if it matches any value of every filter supplied.
+
+Invalid tests and tests that couldn't be run or evaluated are reported as errors
+in the results, the command fails after writing them if there are any.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
-		suite, err := storage.LoadSuite(args[0])
+		suite, err := loadTestSuite(args[0])
 		if err != nil {
 			return err
 		}
 
-		return executor.Execute(cmd.Context(), suite, executor.ExecutionOptions{})
+		results := &executor.ResultSet{}
+		opts := executor.ExecutionOptions{
+			Repeat:  runRepeat,
+			Retries: runRetries,
+			OnResult: func(result *executor.TestCaseResult) {
+				results.Results = append(results.Results, result)
+			},
+		}
+
+		if err := applyRunFilters(&opts); err != nil {
+			return err
+		}
+
+		if err := executor.Execute(cmd.Context(), suite, opts); err != nil {
+			return err
+		}
+
+		if runOutput != "" {
+			if err := executor.WriteResultSet(runOutput, results); err != nil {
+				return err
+			}
+		}
+
+		errored := 0
+		for _, result := range results.Results {
+			if result.GetResult().GetError() != nil {
+				errored++
+			}
+		}
+		if errored > 0 {
+			return fmt.Errorf("%d tests were invalid or couldn't be run", errored)
+		}
+
+		return nil
 	},
 }
 
+// applyRunFilters sets the filters from the command line flags in opts.
+func applyRunFilters(opts *executor.ExecutionOptions) error {
+	opts.ImplementationFilter = executor.NewFilter[*executor.Implementation]()
+	if len(runImplementations) > 0 {
+		opts.ImplementationFilter = opts.ImplementationFilter.WithUid(runImplementations...)
+	}
+
+	opts.VariantFilter = executor.NewFilter[*executor.ImplementationVariant]()
+	if len(runVariants) > 0 {
+		opts.VariantFilter = opts.VariantFilter.WithUid(runVariants...)
+	}
+
+	opts.SpecificationFilter = executor.NewFilter[*executor.Specification]()
+	if len(runSpecifications) > 0 {
+		opts.SpecificationFilter = opts.SpecificationFilter.WithUid(runSpecifications...)
+	}
+
+	opts.SectionFilter = executor.NewFilter[*executor.SpecificationSection]()
+	if len(runSections) > 0 {
+		opts.SectionFilter = opts.SectionFilter.WithUid(runSections...)
+	}
+
+	opts.TestFilter = executor.NewFilter[*executor.TestCase]()
+	if len(runTests) > 0 {
+		opts.TestFilter = opts.TestFilter.WithUid(runTests...)
+	}
+
+	for _, rawSelector := range runSelectors {
+		selector, err := labels.Parse(rawSelector)
+		if err != nil {
+			return fmt.Errorf("invalid selector %q: %w", rawSelector, err)
+		}
+
+		opts.TestFilter = opts.TestFilter.WithSelector(selector)
+	}
+
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(runCmd)
 
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// runCmd.PersistentFlags().String("foo", "", "A help for foo")
+	runCmd.Flags().StringVarP(&runOutput, "output", "o", "", "Path to store the results at")
+	runCmd.Flags().IntVar(&runRepeat, "repeat", 1, "Number of times to run each test, tests with disagreeing attempts are reported as flaky")
+	runCmd.Flags().IntVar(&runRetries, "retries", 0, "Number of times to retry tests that don't pass, tests may override this. Tests that pass on retry are reported as flaky")
 
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// runCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	runCmd.Flags().StringSliceVar(&runImplementations, "implementation", nil, "Only run implementations with the given names")
+	runCmd.Flags().StringSliceVar(&runVariants, "variant", nil, "Only run implementation variants with the given names")
+	runCmd.Flags().StringSliceVar(&runSpecifications, "spec", nil, "Only run tests for specifications with the given names")
+	runCmd.Flags().StringSliceVar(&runSections, "section", nil, "Only run tests for specification sections with the given names")
+	runCmd.Flags().StringSliceVar(&runTests, "test", nil, "Only run tests with the given UIDs")
+	runCmd.Flags().StringArrayVar(&runSelectors, "selector", nil, "Only run tests matching the label selector")
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	schemaSuite  string
+	schemaOutDir string
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema [kind]",
+	Short: "Generate JSON Schema for the YAML files.",
+	Long: `Generates a JSON Schema for a kind of YAML file so editors can validate and
+complete files as they're written.
+
+If --suite is set, expectations are checked against the suite's assertion
+definitions. If --out-dir is set, a <kind>.schema.json file is written for every kind.
+
+Kinds: ` + strings.Join(v1.Kinds(), ", "),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		var definitions []v1.TestSuiteSpecAssertionDefintion
+		if schemaSuite != "" {
+			suite, err := storage.LoadSuite(schemaSuite)
+			if err != nil {
+				return err
+			}
+			definitions = suite.TestSuite.Value.Spec.Assertions.Definitions
+		}
+
+		switch {
+		case schemaOutDir != "" && len(args) > 0:
+			return fmt.Errorf("a kind can't be supplied with --out-dir")
+
+		case schemaOutDir != "":
+			if err := os.MkdirAll(schemaOutDir, 0755); err != nil {
+				return err
+			}
+
+			for _, kind := range v1.Kinds() {
+				out, err := marshalSchema(kind, definitions)
+				if err != nil {
+					return err
+				}
+
+				path := filepath.Join(schemaOutDir, strings.ToLower(kind)+".schema.json")
+				if err := os.WriteFile(path, out, 0644); err != nil {
+					return fmt.Errorf("couldn't write schema %q: %w", path, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+			}
+			return nil
+
+		case len(args) == 0:
+			return fmt.Errorf("requires a kind or --out-dir")
+
+		default:
+			out, err := marshalSchema(args[0], definitions)
+			if err != nil {
+				return err
+			}
+
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		}
+	},
+}
+
+func marshalSchema(kind string, definitions []v1.TestSuiteSpecAssertionDefintion) ([]byte, error) {
+	schema, err := v1.JSONSchema(kind, definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal schema for %q: %w", kind, err)
+	}
+
+	return append(out, '\n'), nil
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().StringVar(&schemaSuite, "suite", "", "Path to a suite to embed assertion input schemas from")
+	schemaCmd.Flags().StringVar(&schemaOutDir, "out-dir", "", "Directory to write a schema for every kind to")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	"github.com/josephlewis42/scheme-compliance/tester/scoring"
+	"github.com/spf13/cobra"
+)
+
+var scoreFormat string
+
+// scoreCmd represents the score command
+var scoreCmd = &cobra.Command{
+	Use:   "score path/to/spec|path/to/bundle path/to/results.json",
+	Short: "Score implementations against each specification section.",
+	Long: `Computes compliance scores for every section of every specification
+targeted by each implementation variant using stored results from run.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		suite, err := loadTestSuite(args[0])
+		if err != nil {
+			return err
+		}
+
+		results, err := executor.ReadResultSet(args[1])
+		if err != nil {
+			return err
+		}
+
+		scores, err := scoring.ScoreSuite(suite, results)
+		if err != nil {
+			return err
+		}
+
+		switch scoreFormat {
+		case "text":
+			writeScoresText(cmd.OutOrStdout(), scores)
+			return nil
+
+		case "json":
+			out, err := json.MarshalIndent(scores, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+
+		default:
+			return fmt.Errorf("unknown format %q, must be one of: text, json", scoreFormat)
+		}
+	},
+}
+
+func writeScoresText(w io.Writer, scores []*scoring.VariantScore) {
+	var writeScore func(score *scoring.Score, depth int)
+	writeScore = func(score *scoring.Score, depth int) {
+		optional := ""
+		if score.Optional {
+			optional = " (optional)"
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s%s%s: %s %.1f%% (%d passed, %d failed, %d skipped, %d errored)\n",
+			strings.Repeat("  ", depth),
+			score.Uid,
+			optional,
+			score.Status,
+			score.Percentage,
+			score.Passed,
+			score.Failed,
+			score.Skipped,
+			score.Errored,
+		)
+
+		for _, child := range score.Sections {
+			writeScore(child, depth+1)
+		}
+	}
+
+	for _, variant := range scores {
+		fmt.Fprintf(w, "%s/%s\n", variant.ImplementationUid, variant.VariantUid)
+		for _, spec := range variant.Specifications {
+			writeScore(spec, 1)
+		}
+
+		for _, deviation := range variant.Deviations {
+			fmt.Fprintf(
+				w,
+				"  deviation: %s (%d expected failures, %d unexpected passes)\n",
+				deviation.Justification,
+				len(deviation.ExpectedFailures),
+				len(deviation.UnexpectedPasses),
+			)
+			if deviation.BugURL != "" {
+				fmt.Fprintf(w, "    bug: %s\n", deviation.BugURL)
+			}
+			for _, uid := range deviation.UnexpectedPasses {
+				fmt.Fprintf(w, "    unexpected pass: %s\n", uid)
+			}
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(scoreCmd)
+
+	scoreCmd.Flags().StringVar(&scoreFormat, "format", "text", "Output format, one of: text, json")
+}
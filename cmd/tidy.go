@@ -1,16 +1,40 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
+
 	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
 	"github.com/spf13/cobra"
 )
 
+var (
+	tidySplit bool
+	tidyCheck bool
+	tidyWrite bool
+)
+
 // tidyCmd represents the tidy command
 var tidyCmd = &cobra.Command{
 	Use:   "tidy path/to/spec",
 	Short: "Organizes the tests under the given directory.",
-	Long:  ``,
-	Args:  cobra.ExactArgs(1),
+	Long: `Fills in missing UUIDs and moves each file to the canonical location for its
+kind and name:
+
+  <suite>.yaml
+  implementations/<name>/<name>.yaml
+  specifications/<name>.yaml
+  tests/<name>.yaml
+
+Files holding several documents are left in place.
+
+If --split is set, every top level context after the first in a test is moved
+into a test file of its own.
+
+By default the changes are printed then written. If --check is set the changes
+are printed but not written and the command fails if there are any, for use in
+CI. If --write is set the changes are written without being printed.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
@@ -20,13 +44,37 @@ var tidyCmd = &cobra.Command{
 		}
 
 		suite.Tidy()
+		if tidySplit {
+			suite.SplitTests()
+		}
+		suite.Reorganize()
+
+		var diff bytes.Buffer
+		suite.Diff(&diff)
+		changed := diff.Len() > 0
+
+		if !tidyWrite {
+			if _, err := diff.WriteTo(cmd.OutOrStdout()); err != nil {
+				return err
+			}
+		}
 
-		suite.Diff(cmd.OutOrStdout())
-		suite.Save()
-		return nil
+		if tidyCheck {
+			if changed {
+				return errors.New("suite isn't tidy, run tidy to apply the changes")
+			}
+			return nil
+		}
+
+		return suite.Save()
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(tidyCmd)
+
+	tidyCmd.Flags().BoolVar(&tidyCheck, "check", false, "Print changes without writing them and fail if there are any")
+	tidyCmd.Flags().BoolVar(&tidyWrite, "write", false, "Write changes without printing them")
+	tidyCmd.MarkFlagsMutuallyExclusive("check", "write")
+	tidyCmd.Flags().BoolVar(&tidySplit, "split", false, "Split tests with several top level contexts into separate files")
 }
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/scoring"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+	"github.com/josephlewis42/scheme-compliance/tester/watch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchImplementation string
+	watchVariant        string
+	watchInterval       time.Duration
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch path/to/spec",
+	Short: "Re-check and re-run tests when the suite changes.",
+	Long: `Watches the YAML and JSON files under the suite's directory and the files
+tests read content from.
+
+On every change the suite is reloaded and validated, then the tests whose hydrated
+content changed are run. Changes to files holding anything other than tests re-run
+every test, including changes made while the suite was invalid.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		session := &watchSession{
+			root:    args[0],
+			out:     cmd.OutOrStdout(),
+			results: make(map[string]*executor.TestCaseResult),
+		}
+
+		return watch.Poll(ctx, session.root, watchInterval, session.testFilePaths, func(changed []string) {
+			session.update(ctx, changed)
+		})
+	},
+}
+
+// watchSession holds the state carried between reloads of a watched suite.
+type watchSession struct {
+	root string
+	out  io.Writer
+
+	hashes  watch.TestHashes
+	results map[string]*executor.TestCaseResult
+
+	// kinds of the documents in each file when the suite was last loaded.
+	kinds map[string][]string
+	// testFiles holds the files tests read content from when the suite was
+	// last loaded.
+	testFiles map[string]bool
+	// needsFullRun is set when something other than a test changed and
+	// cleared once every test has been re-run.
+	needsFullRun bool
+}
+
+func (ws *watchSession) update(ctx context.Context, changed []string) {
+	fmt.Fprintf(ws.out, "\n=== %s\n", time.Now().Format(time.TimeOnly))
+	for _, path := range changed {
+		fmt.Fprintf(ws.out, "changed: %s\n", path)
+	}
+
+	suite, err := storage.LoadSuite(ws.root)
+	if err != nil {
+		if !ws.onlyTestsChanged(changed, nil, nil) {
+			ws.needsFullRun = true
+		}
+		fmt.Fprintf(ws.out, "couldn't load suite: %v\n", err)
+		return
+	}
+
+	kinds := suite.SourceKinds()
+	testFiles := make(map[string]bool)
+	for _, path := range suite.TestFilePaths() {
+		testFiles[path] = true
+	}
+	if !ws.onlyTestsChanged(changed, kinds, testFiles) {
+		ws.needsFullRun = true
+	}
+	ws.kinds = kinds
+	ws.testFiles = testFiles
+
+	var vs validation.ValidationSummary
+	suite.RunValidation(func(name string, v *validation.Validator) {
+		for _, result := range v.Results {
+			fmt.Fprintf(ws.out, "%s: %s\n", name, result.String())
+		}
+
+		vs.Update(v)
+	})
+
+	fmt.Fprintf(
+		ws.out,
+		"Validation: %d Errors, %d Warnings, %d Infos\n",
+		vs.ErrorCount,
+		vs.WarningCount,
+		vs.InfoCount,
+	)
+
+	if vs.ErrorCount > 0 {
+		fmt.Fprintln(ws.out, "Not running tests until errors are fixed.")
+		return
+	}
+
+	hashes, err := watch.HashTests(suite.ListTests())
+	if err != nil {
+		fmt.Fprintf(ws.out, "couldn't hash tests: %v\n", err)
+		return
+	}
+
+	previous := ws.hashes
+	if ws.needsFullRun {
+		// Implementations, specifications or assertions may change any result.
+		previous = nil
+		ws.results = make(map[string]*executor.TestCaseResult)
+	}
+
+	toRun := previous.Changed(hashes)
+
+	for key, result := range ws.results {
+		if _, ok := hashes[result.GetTestUid()]; !ok {
+			delete(ws.results, key)
+		}
+	}
+
+	if len(toRun) > 0 {
+		fmt.Fprintf(ws.out, "Running %d changed tests\n", len(toRun))
+
+		opts := executor.ExecutionOptions{
+			ImplementationFilter: executor.NewFilter[*executor.Implementation](),
+			VariantFilter:        executor.NewFilter[*executor.ImplementationVariant](),
+			TestFilter:           executor.NewFilter[*executor.TestCase]().WithUid(toRun...),
+			OnResult: func(result *executor.TestCaseResult) {
+				key := strings.Join([]string{result.GetImplementationUid(), result.GetVariantUid(), result.GetTestUid()}, "/")
+				ws.results[key] = result
+			},
+		}
+
+		if watchImplementation != "" {
+			opts.ImplementationFilter = opts.ImplementationFilter.WithUid(watchImplementation)
+		}
+
+		if watchVariant != "" {
+			opts.VariantFilter = opts.VariantFilter.WithUid(watchVariant)
+		}
+
+		if err := executor.Execute(ctx, suite, opts); err != nil {
+			// The tests are run again on the next change.
+			fmt.Fprintf(ws.out, "couldn't run tests: %v\n", err)
+			return
+		}
+	}
+
+	ws.hashes = hashes
+	ws.needsFullRun = false
+	ws.writeSummary(suite)
+}
+
+// onlyTestsChanged checks if every changed file held only tests or was read
+// by tests when the suite was last loaded and, if kinds is set, still does.
+// Files with unknown contents are assumed to hold something else.
+func (ws *watchSession) onlyTestsChanged(changed []string, kinds map[string][]string, testFiles map[string]bool) bool {
+	if len(changed) == 0 {
+		return false
+	}
+
+	for _, path := range changed {
+		if ws.testFiles[path] || testFiles[path] {
+			continue
+		}
+
+		before, known := ws.kinds[path]
+		after, loaded := kinds[path]
+		if !known && !loaded {
+			return false
+		}
+
+		for _, kind := range append(before, after...) {
+			if kind != v1.KindTest {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// testFilePaths returns the files tests read content from when the suite was
+// last loaded.
+func (ws *watchSession) testFilePaths() (out []string) {
+	for path := range ws.testFiles {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return
+}
+
+func (ws *watchSession) writeSummary(suite *storage.Suite) {
+	var keys []string
+	for key := range ws.results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := &executor.ResultSet{}
+	for _, key := range keys {
+		results.Results = append(results.Results, ws.results[key])
+	}
+
+	scores, err := scoring.ScoreSuite(suite, results)
+	if err != nil {
+		fmt.Fprintf(ws.out, "couldn't score results: %v\n", err)
+		return
+	}
+
+	var selected []*scoring.VariantScore
+	for _, score := range scores {
+		if watchImplementation != "" && score.ImplementationUid != watchImplementation {
+			continue
+		}
+		if watchVariant != "" && score.VariantUid != watchVariant {
+			continue
+		}
+		selected = append(selected, score)
+	}
+
+	writeScoresText(ws.out, selected)
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().StringVar(&watchImplementation, "implementation", "", "Only run tests against the implementation with the given name")
+	watchCmd.Flags().StringVar(&watchVariant, "variant", "", "Only run tests against implementation variants with the given name")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Second, "How often to check for changes")
+}
@@ -0,0 +1,136 @@
+// Command gendocs extracts the doc comments of struct types and their fields
+// in a package so they're available at runtime.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "zz_generated.docs.go", "file to write")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasPrefix(name, "zz_generated")
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(pkgs) != 1 {
+		log.Fatalf("expected one package, got %d", len(pkgs))
+	}
+
+	var pkgName string
+	typeDocs := make(map[string]string)
+	fieldDocs := make(map[string]map[string]string)
+
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+
+					doc := typeSpec.Doc
+					if doc == nil && len(genDecl.Specs) == 1 {
+						doc = genDecl.Doc
+					}
+					if text := cleanDoc(doc); text != "" {
+						typeDocs[typeSpec.Name.Name] = text
+					}
+
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+
+					for _, field := range structType.Fields.List {
+						text := cleanDoc(field.Doc)
+						if text == "" {
+							text = cleanDoc(field.Comment)
+						}
+
+						if text == "" {
+							continue
+						}
+
+						for _, name := range field.Names {
+							if fieldDocs[typeSpec.Name.Name] == nil {
+								fieldDocs[typeSpec.Name.Name] = make(map[string]string)
+							}
+							fieldDocs[typeSpec.Name.Name][name.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by gendocs. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+
+	fmt.Fprintln(&buf, "// typeDocs holds the doc comments of types keyed by type name.")
+	fmt.Fprintln(&buf, "var typeDocs = map[string]string{")
+	for _, name := range sortedKeys(typeDocs) {
+		fmt.Fprintf(&buf, "%q: %q,\n", name, typeDocs[name])
+	}
+	fmt.Fprintln(&buf, "}")
+	fmt.Fprintln(&buf)
+
+	fmt.Fprintln(&buf, "// fieldDocs holds the doc comments of struct fields keyed by type then field name.")
+	fmt.Fprintln(&buf, "var fieldDocs = map[string]map[string]string{")
+	for _, typeName := range sortedKeys(fieldDocs) {
+		fmt.Fprintf(&buf, "%q: {\n", typeName)
+		for _, fieldName := range sortedKeys(fieldDocs[typeName]) {
+			fmt.Fprintf(&buf, "%q: %q,\n", fieldName, fieldDocs[typeName][fieldName])
+		}
+		fmt.Fprintln(&buf, "},")
+	}
+	fmt.Fprintln(&buf, "}")
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func cleanDoc(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(group.Text())
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
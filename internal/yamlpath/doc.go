@@ -0,0 +1,2 @@
+// Package yamlpath resolves validation field paths to nodes in YAML documents.
+package yamlpath
@@ -0,0 +1,82 @@
+package yamlpath
+
+import (
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lookup finds the key and value nodes of a field in a mapping.
+func Lookup(node *yaml.Node, key string) (keyNode, valueNode *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// FindKey returns the first mapping key with the given name.
+func FindKey(node *yaml.Node, name string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				return node.Content[i]
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		if found := FindKey(child, name); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+var fieldPathSegment = regexp.MustCompile(`\.([^.\[]+)|\[([^\]]*)\]`)
+
+// Find returns the deepest node matching a validation field path such as
+// ".tests[0].case.input", keys are returned in place of their values.
+func Find(root *yaml.Node, fieldPath string) *yaml.Node {
+	current, found := root, root
+
+	for _, match := range fieldPathSegment.FindAllStringSubmatch(fieldPath, -1) {
+		segment := match[1] + match[2]
+
+		switch {
+		case current == nil:
+			return found
+
+		case current.Kind == yaml.MappingNode:
+			key, value := Lookup(current, segment)
+			if key == nil {
+				return found
+			}
+			current, found = value, key
+
+		case current.Kind == yaml.SequenceNode:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(current.Content) {
+				return found
+			}
+			current, found = current.Content[idx], current.Content[idx]
+
+		default:
+			return found
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,310 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+)
+
+// Change classifies how a test's result differs between two result sets.
+type Change string
+
+const (
+	// ChangeNewlyPassing indicates a test that didn't pass now passes.
+	ChangeNewlyPassing Change = "newly-passing"
+	// ChangeNewlyFailing indicates a test that didn't fail now fails.
+	ChangeNewlyFailing Change = "newly-failing"
+	// ChangeStillFailing indicates a test failed in both result sets.
+	ChangeStillFailing Change = "still-failing"
+	// ChangeStatusChanged indicates a test that neither fails nor newly passes
+	// but has a different outcome, like a passing test now skipped or covered
+	// by a deviation.
+	ChangeStatusChanged Change = "status-changed"
+	// ChangeAdded indicates a test only present in the new result set that
+	// doesn't fail.
+	ChangeAdded Change = "added"
+	// ChangeAddedFailing indicates a test only present in the new result set
+	// that fails.
+	ChangeAddedFailing Change = "added-failing"
+	// ChangeRemoved indicates a test only present in the old result set.
+	ChangeRemoved Change = "removed"
+	// ChangeUnchanged indicates a test with the same non-failing outcome in both.
+	ChangeUnchanged Change = "unchanged"
+)
+
+// Changes lists every change in the order they're reported.
+var Changes = []Change{
+	ChangeNewlyFailing,
+	ChangeAddedFailing,
+	ChangeStillFailing,
+	ChangeNewlyPassing,
+	ChangeStatusChanged,
+	ChangeAdded,
+	ChangeRemoved,
+	ChangeUnchanged,
+}
+
+// Entry is the comparison of a single test for a single implementation variant.
+type Entry struct {
+	ImplementationUid string `json:"implementationUid"`
+	VariantUid        string `json:"variantUid"`
+	TestUid           string `json:"testUid"`
+
+	Change Change `json:"change"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+
+	// Message holds the failure or error message from the new result, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// Comparison holds the differences between two result sets.
+type Comparison struct {
+	Entries []Entry `json:"entries"`
+}
+
+type resultKey struct {
+	implementationUid string
+	variantUid        string
+	testUid           string
+}
+
+func indexResults(results *executor.ResultSet) map[resultKey]*executor.TestResult {
+	out := make(map[resultKey]*executor.TestResult)
+	for _, result := range results.GetResults() {
+		out[resultKey{
+			implementationUid: result.GetImplementationUid(),
+			variantUid:        result.GetVariantUid(),
+			testUid:           result.GetTestUid(),
+		}] = result.GetResult()
+	}
+
+	return out
+}
+
+// Compare joins the results by implementation variant and test UID and
+// classifies how each changed from before to after.
+func Compare(before, after *executor.ResultSet) *Comparison {
+	beforeResults := indexResults(before)
+	afterResults := indexResults(after)
+
+	keys := make(map[resultKey]bool)
+	for key := range beforeResults {
+		keys[key] = true
+	}
+	for key := range afterResults {
+		keys[key] = true
+	}
+
+	out := &Comparison{}
+	for key := range keys {
+		beforeResult, inBefore := beforeResults[key]
+		afterResult, inAfter := afterResults[key]
+
+		entry := Entry{
+			ImplementationUid: key.implementationUid,
+			VariantUid:        key.variantUid,
+			TestUid:           key.testUid,
+			Message:           message(afterResult),
+		}
+
+		if inBefore {
+			entry.Before = StatusName(beforeResult)
+		}
+		if inAfter {
+			entry.After = StatusName(afterResult)
+		}
+
+		switch {
+		case !inBefore && isFailing(afterResult):
+			entry.Change = ChangeAddedFailing
+		case !inBefore:
+			entry.Change = ChangeAdded
+		case !inAfter:
+			entry.Change = ChangeRemoved
+		case isFailing(beforeResult) && isFailing(afterResult):
+			entry.Change = ChangeStillFailing
+		case isFailing(afterResult):
+			entry.Change = ChangeNewlyFailing
+		case isPassing(afterResult) && !isPassing(beforeResult):
+			entry.Change = ChangeNewlyPassing
+		case entry.Before != entry.After:
+			entry.Change = ChangeStatusChanged
+		default:
+			entry.Change = ChangeUnchanged
+		}
+
+		out.Entries = append(out.Entries, entry)
+	}
+
+	// Ensure outputs are deterministic.
+	sort.Slice(out.Entries, func(i, j int) bool {
+		a, b := out.Entries[i], out.Entries[j]
+		switch {
+		case a.ImplementationUid != b.ImplementationUid:
+			return a.ImplementationUid < b.ImplementationUid
+		case a.VariantUid != b.VariantUid:
+			return a.VariantUid < b.VariantUid
+		default:
+			return a.TestUid < b.TestUid
+		}
+	})
+
+	return out
+}
+
+// Regressions returns the number of tests that are newly failing, including
+// added tests that fail.
+func (c *Comparison) Regressions() int {
+	return len(c.WithChange(ChangeNewlyFailing)) + len(c.WithChange(ChangeAddedFailing))
+}
+
+// WithChange returns the entries with the given change.
+func (c *Comparison) WithChange(change Change) (out []Entry) {
+	for _, entry := range c.Entries {
+		if entry.Change == change {
+			out = append(out, entry)
+		}
+	}
+
+	return
+}
+
+// WriteText writes a human-readable summary of the comparison, unchanged
+// tests are only counted.
+func (c *Comparison) WriteText(w io.Writer) {
+	for _, change := range Changes {
+		entries := c.WithChange(change)
+		fmt.Fprintf(w, "%s: %d\n", change, len(entries))
+
+		if change == ChangeUnchanged {
+			continue
+		}
+
+		for _, entry := range entries {
+			fmt.Fprintf(
+				w,
+				"- %s/%s %s (%s -> %s)",
+				entry.ImplementationUid,
+				entry.VariantUid,
+				entry.TestUid,
+				orMissing(entry.Before),
+				orMissing(entry.After),
+			)
+			if entry.Message != "" {
+				fmt.Fprintf(w, ": %s", entry.Message)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// WriteMarkdown writes the comparison as a Markdown report, unchanged
+// tests are only counted.
+func (c *Comparison) WriteMarkdown(w io.Writer) {
+	fmt.Fprintln(w, "# Result comparison")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Change | Count |")
+	fmt.Fprintln(w, "| --- | --- |")
+	for _, change := range Changes {
+		fmt.Fprintf(w, "| %s | %d |\n", change, len(c.WithChange(change)))
+	}
+
+	for _, change := range Changes {
+		entries := c.WithChange(change)
+		if change == ChangeUnchanged || len(entries) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s\n", change)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Implementation | Variant | Test | Before | After | Message |")
+		fmt.Fprintln(w, "| --- | --- | --- | --- | --- | --- |")
+		for _, entry := range entries {
+			fmt.Fprintf(
+				w,
+				"| %s | %s | `%s` | %s | %s | %s |\n",
+				entry.ImplementationUid,
+				entry.VariantUid,
+				entry.TestUid,
+				orMissing(entry.Before),
+				orMissing(entry.After),
+				escapeMarkdownCell(entry.Message),
+			)
+		}
+	}
+}
+
+// StatusName returns a short name for the status of a result.
+func StatusName(result *executor.TestResult) string {
+	switch result.GetStatus().(type) {
+	case *executor.TestResult_Success_:
+		return "success"
+	case *executor.TestResult_Failure_:
+		return "failure"
+	case *executor.TestResult_Example:
+		return "example"
+	case *executor.TestResult_Skipped_:
+		return "skipped"
+	case *executor.TestResult_Error_:
+		return "error"
+	case *executor.TestResult_ExpectedFailure_:
+		return "expected-failure"
+	case *executor.TestResult_UnexpectedPass_:
+		return "unexpected-pass"
+	case *executor.TestResult_Flaky_:
+		return "flaky"
+	default:
+		return "unknown"
+	}
+}
+
+func isPassing(result *executor.TestResult) bool {
+	return result.GetSuccess() != nil || result.GetUnexpectedPass() != nil
+}
+
+// isFailing checks whether a result failed, failures covered by a documented
+// deviation aren't counted.
+func isFailing(result *executor.TestResult) bool {
+	return result.GetFailure() != nil || result.GetError() != nil || result.GetFlaky() != nil
+}
+
+func message(result *executor.TestResult) string {
+	switch {
+	case result.GetFailure() != nil:
+		return result.GetFailure().GetMessage()
+	case result.GetError() != nil:
+		return result.GetError().GetMessage()
+	case result.GetFlaky() != nil:
+		return fmt.Sprintf("%d distinct outputs", len(result.GetFlaky().GetDistinctOutputs()))
+	case result.GetExpectedFailure() != nil:
+		return deviationMessage(result.GetExpectedFailure().GetDeviation())
+	case result.GetUnexpectedPass() != nil:
+		return deviationMessage(result.GetUnexpectedPass().GetDeviation())
+	default:
+		return ""
+	}
+}
+
+func deviationMessage(deviation *executor.Deviation) string {
+	if deviation.GetBugUrl() == "" {
+		return fmt.Sprintf("deviation: %s", deviation.GetJustification())
+	}
+
+	return fmt.Sprintf("deviation: %s (%s)", deviation.GetJustification(), deviation.GetBugUrl())
+}
+
+func orMissing(status string) string {
+	if status == "" {
+		return "missing"
+	}
+	return status
+}
+
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", "<br>").Replace(text)
+}
@@ -0,0 +1,70 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+)
+
+func TestCompare(t *testing.T) {
+	success := &executor.TestResult{Status: &executor.TestResult_Success_{Success: &executor.TestResult_Success{}}}
+	failure := &executor.TestResult{Status: &executor.TestResult_Failure_{Failure: &executor.TestResult_Failure{Message: "boom"}}}
+	skipped := &executor.TestResult{Status: &executor.TestResult_Skipped_{Skipped: &executor.TestResult_Skipped{}}}
+	expectedFailure := &executor.TestResult{Status: &executor.TestResult_ExpectedFailure_{ExpectedFailure: &executor.TestResult_ExpectedFailure{}}}
+
+	cases := map[string]struct {
+		before, after *executor.TestResult
+		want          Change
+		regression    bool
+	}{
+		"same success":                         {before: success, after: success, want: ChangeUnchanged},
+		"same skip":                            {before: skipped, after: skipped, want: ChangeUnchanged},
+		"success to failure":                   {before: success, after: failure, want: ChangeNewlyFailing, regression: true},
+		"failure to failure":                   {before: failure, after: failure, want: ChangeStillFailing},
+		"failure to success":                   {before: failure, after: success, want: ChangeNewlyPassing},
+		"skipped to success":                   {before: skipped, after: success, want: ChangeNewlyPassing},
+		"success to skipped":                   {before: success, after: skipped, want: ChangeStatusChanged},
+		"success to expected failure":          {before: success, after: expectedFailure, want: ChangeStatusChanged},
+		"expected failure to skipped":          {before: expectedFailure, after: skipped, want: ChangeStatusChanged},
+		"failure to expected failure":          {before: failure, after: expectedFailure, want: ChangeStatusChanged},
+		"added success":                        {after: success, want: ChangeAdded},
+		"added failure":                        {after: failure, want: ChangeAddedFailing, regression: true},
+		"removed success":                      {before: success, want: ChangeRemoved},
+		"removed failure":                      {before: failure, want: ChangeRemoved},
+		"added expected failure":               {after: expectedFailure, want: ChangeAdded},
+		"expected failure to expected failure": {before: expectedFailure, after: expectedFailure, want: ChangeUnchanged},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resultSet := func(result *executor.TestResult) *executor.ResultSet {
+				if result == nil {
+					return &executor.ResultSet{}
+				}
+				return &executor.ResultSet{Results: []*executor.TestCaseResult{{
+					ImplementationUid: "impl",
+					VariantUid:        "default",
+					TestUid:           "test",
+					Result:            result,
+				}}}
+			}
+
+			comparison := Compare(resultSet(tc.before), resultSet(tc.after))
+			if len(comparison.Entries) != 1 {
+				t.Fatalf("Compare() entries = %d, want 1", len(comparison.Entries))
+			}
+
+			if got := comparison.Entries[0].Change; got != tc.want {
+				t.Errorf("Compare() change = %q, want %q", got, tc.want)
+			}
+
+			wantRegressions := 0
+			if tc.regression {
+				wantRegressions = 1
+			}
+			if got := comparison.Regressions(); got != wantRegressions {
+				t.Errorf("Regressions() = %d, want %d", got, wantRegressions)
+			}
+		})
+	}
+}
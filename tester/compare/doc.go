@@ -0,0 +1,2 @@
+// Package compare finds the differences between two sets of stored results.
+package compare
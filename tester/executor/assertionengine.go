@@ -73,34 +73,80 @@ type testSettings struct {
 }
 
 func (runtime *Runtime) CheckTestInput(evalTest *EvalTest) (*testSettings, error) {
-	functionName, foundFunctionName := runtime.funcLookup[evalTest.ExpectationType]
+	settings, inputErrors, err := runtime.checkInput(evalTest.ExpectationType, evalTest.ExpectationOptionsJson)
+	switch {
+	case err != nil:
+		return nil, err
+	case len(inputErrors) > 0:
+		var messages []string
+		for _, inputError := range inputErrors {
+			messages = append(messages, inputError.String())
+		}
+		return nil, fmt.Errorf("validation errors: %q", messages)
+	default:
+		return settings, nil
+	}
+}
+
+// InputError is a violation of an assertion's input schema.
+type InputError struct {
+	// Dot separated path to the invalid value within the options, empty for the root.
+	Field   string
+	Message string
+}
+
+func (e InputError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// CheckExpectationInput validates the options of an expectation against the
+// input schema of its assertion, an error is returned if the options can't be
+// checked at all.
+func (runtime *Runtime) CheckExpectationInput(expectationType, optionsJSON string) ([]InputError, error) {
+	_, inputErrors, err := runtime.checkInput(expectationType, optionsJSON)
+	return inputErrors, err
+}
+
+func (runtime *Runtime) checkInput(expectationType, optionsJSON string) (*testSettings, []InputError, error) {
+	functionName, foundFunctionName := runtime.funcLookup[expectationType]
 	if !foundFunctionName {
-		return nil, fmt.Errorf("couldn't find function for expectation type %q", evalTest.ExpectationType)
+		return nil, nil, fmt.Errorf("couldn't find function for expectation type %q", expectationType)
 	}
 
-	schema, foundSchema := runtime.schemaDefinitions[evalTest.ExpectationType]
+	schema, foundSchema := runtime.schemaDefinitions[expectationType]
 	if !foundSchema {
-		return nil, fmt.Errorf("couldn't find schema for expectation type %q", evalTest.ExpectationType)
+		return nil, nil, fmt.Errorf("couldn't find schema for expectation type %q", expectationType)
 	}
 
 	var parsedOptionsJson interface{}
-	if err := json.Unmarshal([]byte(evalTest.ExpectationOptionsJson), &parsedOptionsJson); err != nil {
-		return nil, fmt.Errorf("options are invalid JSON: %e", err)
+	if err := json.Unmarshal([]byte(optionsJSON), &parsedOptionsJson); err != nil {
+		return nil, nil, fmt.Errorf("options are invalid JSON: %e", err)
 	}
 
 	result, err := schema.Validate(gojsonschema.NewGoLoader(parsedOptionsJson))
 	if err != nil {
-		return nil, fmt.Errorf("couldn't load expectation options: %e", err)
+		return nil, nil, fmt.Errorf("couldn't load expectation options: %e", err)
 	}
 
-	if result.Valid() {
-		return &testSettings{
-			functionName:  functionName,
-			parsedOptions: parsedOptionsJson,
-		}, nil
+	if !result.Valid() {
+		var inputErrors []InputError
+		for _, resultError := range result.Errors() {
+			field := resultError.Field()
+			if field == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+				field = ""
+			}
+			inputErrors = append(inputErrors, InputError{Field: field, Message: resultError.Description()})
+		}
+		return nil, inputErrors, nil
 	}
 
-	return nil, fmt.Errorf("validation errors: %q", result.Errors())
+	return &testSettings{
+		functionName:  functionName,
+		parsedOptions: parsedOptionsJson,
+	}, nil, nil
 }
 
 func (runtime *Runtime) EvaluateTestResult(ctx context.Context, testCase *TestCase, output *ProcessOutput) (*TestResult, error) {
@@ -0,0 +1,96 @@
+package executor
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// runAttempts runs an evaluated test opts.Repeat times then retries it while
+// the latest attempt doesn't pass. Other test types are only attempted once.
+//
+// Retries don't turn a failure into a pass: attempts that disagree are
+// summarized as flaky, so a test that passes on retry is reported as flaky.
+func runAttempts(ctx context.Context, runtime *Runtime, test *TestCase, variant *ImplementationVariant, opts ExecutionOptions) []*TestAttempt {
+	eval := test.GetEval()
+	if eval == nil {
+		return []*TestAttempt{runTest(ctx, runtime, test, variant)}
+	}
+
+	repeat := opts.Repeat
+	if repeat < 1 {
+		repeat = 1
+	}
+
+	retries := opts.Retries
+	if eval.Retries != nil {
+		retries = int(eval.GetRetries())
+	}
+
+	var attempts []*TestAttempt
+	for i := 0; i < repeat; i++ {
+		attempts = append(attempts, runTest(ctx, runtime, test, variant))
+	}
+
+	for i := 0; i < retries && attempts[len(attempts)-1].GetResult().GetSuccess() == nil; i++ {
+		attempts = append(attempts, runTest(ctx, runtime, test, variant))
+	}
+
+	return attempts
+}
+
+// summarizeAttempts returns the result of the last attempt if all attempts
+// agree on the outcome, otherwise the test is flaky.
+func summarizeAttempts(attempts []*TestAttempt) *TestResult {
+	last := attempts[len(attempts)-1].GetResult()
+
+	flaky := false
+	for _, attempt := range attempts {
+		if outcomeKind(attempt.GetResult()) != outcomeKind(last) {
+			flaky = true
+		}
+	}
+
+	if !flaky {
+		return last
+	}
+
+	var distinct []*ProcessOutput
+	for _, attempt := range attempts {
+		output := attempt.GetOutput()
+		if output == nil {
+			continue
+		}
+
+		seen := false
+		for _, existing := range distinct {
+			seen = seen || proto.Equal(existing, output)
+		}
+
+		if !seen {
+			distinct = append(distinct, output)
+		}
+	}
+
+	return &TestResult{
+		Status: &TestResult_Flaky_{
+			Flaky: &TestResult_Flaky{DistinctOutputs: distinct},
+		},
+	}
+}
+
+// outcomeKind returns the name of the type of a result's status.
+func outcomeKind(result *TestResult) string {
+	switch result.GetStatus().(type) {
+	case *TestResult_Success_:
+		return "success"
+	case *TestResult_Failure_:
+		return "failure"
+	case *TestResult_Error_:
+		return "error"
+	case *TestResult_Example:
+		return "example"
+	default:
+		return "other"
+	}
+}
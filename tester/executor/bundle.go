@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ TestSuite = (*Bundle)(nil)
+
+// ListTests implements TestSuite.
+func (b *Bundle) ListTests() []*TestCase {
+	return b.GetTests()
+}
+
+// ListImplementations implements TestSuite.
+func (b *Bundle) ListImplementations() []*Implementation {
+	return b.GetImplementations()
+}
+
+// ListSpecifications implements TestSuite.
+func (b *Bundle) ListSpecifications() []*Specification {
+	return b.GetSpecifications()
+}
+
+// TestAssertionEngine implements TestSuite.
+func (b *Bundle) TestAssertionEngine() (*Runtime, error) {
+	return NewRuntimeFromConfig(b.GetAssertionConfig())
+}
+
+// NewRuntimeFromConfig creates a runtime with every assertion in the config registered.
+func NewRuntimeFromConfig(cfg *AssertionConfig) (*Runtime, error) {
+	runtime, err := NewRuntime(cfg.GetScript())
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, defn := range cfg.GetDefinitions() {
+		if err := runtime.AddAssertion(defn.GetName(), defn.GetFunctionName(), []byte(defn.GetInputSchemaJson())); err != nil {
+			errs = append(errs, fmt.Errorf("couldn't register %s: %w", defn.GetName(), err))
+		}
+	}
+
+	return runtime, errors.Join(errs...)
+}
+
+// ReadBundle reads a bundle from the given path, files with a .json extension
+// are read as JSON and all others as binary protocol buffers.
+func ReadBundle(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", path, err)
+	}
+
+	return DecodeBundle(path, data)
+}
+
+// DecodeBundle decodes a bundle read from a file with the given name, names
+// with a .json extension are decoded as JSON and all others as binary protocol
+// buffers.
+func DecodeBundle(name string, data []byte) (*Bundle, error) {
+	out := &Bundle{}
+	var err error
+	if filepath.Ext(name) == ".json" {
+		err = protojson.Unmarshal(data, out)
+	} else {
+		err = proto.Unmarshal(data, out)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode %s: %w", name, err)
+	}
+
+	return out, nil
+}
+
+// WriteBundle stores a bundle at the given path, files with a .json extension
+// are written as JSON and all others as binary protocol buffers.
+func WriteBundle(path string, bundle *Bundle) error {
+	var data []byte
+	var err error
+	if filepath.Ext(path) == ".json" {
+		data, err = marshalIndentedJSON(bundle)
+	} else {
+		data, err = proto.MarshalOptions{Deterministic: true}.Marshal(bundle)
+	}
+
+	if err != nil {
+		return fmt.Errorf("couldn't encode bundle: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("couldn't write %s: %w", path, err)
+	}
+
+	return nil
+}
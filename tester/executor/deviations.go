@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// deviationMatcher finds the documented deviation that applies to a test.
+type deviationMatcher struct {
+	deviations []*Deviation
+	uids       []map[string]bool
+	selectors  []labels.Selector
+}
+
+func newDeviationMatcher(deviations []*Deviation) (*deviationMatcher, error) {
+	out := &deviationMatcher{deviations: deviations}
+
+	for idx, deviation := range deviations {
+		uids := make(map[string]bool)
+		for _, uid := range deviation.GetTestUids() {
+			uids[uid] = true
+		}
+		out.uids = append(out.uids, uids)
+
+		var selector labels.Selector
+		if deviation.GetTestSelector() != "" {
+			parsed, err := labels.Parse(deviation.GetTestSelector())
+			if err != nil {
+				return nil, fmt.Errorf("deviation %d has invalid selector: %w", idx, err)
+			}
+			selector = parsed
+		}
+		out.selectors = append(out.selectors, selector)
+	}
+
+	return out, nil
+}
+
+// Find returns the first deviation that applies to the test or nil if none do.
+func (dm *deviationMatcher) Find(test *TestCase) *Deviation {
+	metadata := test.GetMetadata()
+
+	for idx, deviation := range dm.deviations {
+		if dm.uids[idx][metadata.GetUid()] {
+			return deviation
+		}
+
+		if selector := dm.selectors[idx]; selector != nil && selector.Matches(labels.Set(metadata.GetLabels())) {
+			return deviation
+		}
+	}
+
+	return nil
+}
+
+// applyDeviation converts the result of a test covered by a deviation into
+// an expected failure or unexpected pass.
+func applyDeviation(result *TestResult, deviation *Deviation) *TestResult {
+	if deviation == nil {
+		return result
+	}
+
+	switch status := result.GetStatus().(type) {
+	case *TestResult_Success_:
+		return &TestResult{
+			Status: &TestResult_UnexpectedPass_{
+				UnexpectedPass: &TestResult_UnexpectedPass{Deviation: deviation},
+			},
+		}
+
+	case *TestResult_Failure_:
+		return &TestResult{
+			Status: &TestResult_ExpectedFailure_{
+				ExpectedFailure: &TestResult_ExpectedFailure{
+					Message:   status.Failure.GetMessage(),
+					Deviation: deviation,
+				},
+			},
+		}
+
+	case *TestResult_Error_:
+		return &TestResult{
+			Status: &TestResult_ExpectedFailure_{
+				ExpectedFailure: &TestResult_ExpectedFailure{
+					Message:   status.Error.GetMessage(),
+					Deviation: deviation,
+				},
+			},
+		}
+
+	default:
+		return result
+	}
+}
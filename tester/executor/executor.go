@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/josephlewis42/scheme-compliance/internal/specctx"
@@ -14,6 +16,16 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 )
 
+// ProgramFileName is the name of a test's program in $(PROGRAM_DIR), test files
+// can't use it.
+const ProgramFileName = "program"
+
+// ConflictsWithProgram returns true if a test file with the slash separated
+// name would replace the program or need it to be a directory.
+func ConflictsWithProgram(name string) bool {
+	return strings.Split(name, "/")[0] == ProgramFileName
+}
+
 type ExecutionOptions struct {
 	// Filter for which specifications to run.
 	SpecificationFilter Filter[*Specification]
@@ -21,11 +33,28 @@ type ExecutionOptions struct {
 	// Filter for which implementations to run.
 	ImplementationFilter Filter[*Implementation]
 
+	// Filter for which implementation variants to run.
+	VariantFilter Filter[*ImplementationVariant]
+
+	// Filter for which specification sections to run tests for, matching a
+	// section includes all of its subsections.
+	SectionFilter Filter[*SpecificationSection]
+
 	// Filter for which tests to run.
 	TestFilter Filter[*TestCase]
 
 	// Only execute missing items.
 	OnlyMissing bool
+
+	// Number of times to run each test, defaults to once.
+	Repeat int
+
+	// Number of times to retry a test that doesn't pass, tests may override this.
+	// A test that passes on retry is reported as flaky rather than passing.
+	Retries int
+
+	// OnResult is called with the result of each test as it completes.
+	OnResult func(*TestCaseResult)
 }
 
 func Execute(ctx context.Context, suite TestSuite, opts ExecutionOptions) error {
@@ -43,56 +72,56 @@ func Execute(ctx context.Context, suite TestSuite, opts ExecutionOptions) error
 
 	for _, impl := range implementations {
 		log := log.With(slog.String("implementation", impl.GetMetadata().GetUid()))
-		for _, variant := range impl.Variants {
+		for _, variant := range opts.VariantFilter.Apply(impl.Variants) {
 			log := log.With(slog.String("variant", variant.GetMetadata().GetUid()))
 
-			specifications := opts.SpecificationFilter.
+			deviations, err := newDeviationMatcher(variant.GetDeviations())
+			if err != nil {
+				return err
+			}
+
+			specifications := NewFilter[*Specification]().
 				WithUid(variant.GetSpecificationUids()...).
 				Apply(allSpecifications)
 
-			testsToRun := NewFilter[*TestCase]()
+			var testsToRun []*TestCase
+			selected := make(map[string]bool)
 			for _, spec := range specifications {
-				log := log.With(slog.String("specification", spec.GetMetadata().GetUid()))
+				testFilters, err := getTestFilters(spec.Sections, opts.SectionFilter, false)
+				if err != nil {
+					return err
+				}
 
-				testFilters, err := getTestFilters(spec.Sections)
+				applicable, err := spec.ApplicableTests(allTests)
 				if err != nil {
 					return err
 				}
 
 				for _, filter := range testFilters {
-					filter.ForEach(allTests, func(matching *TestCase) {
-						testsToRun = testsToRun.WithUid(matching.GetMetadata().GetUid())
+					filter.ForEach(applicable, func(matching *TestCase) {
+						if uid := matching.GetMetadata().GetUid(); !selected[uid] {
+							selected[uid] = true
+							testsToRun = append(testsToRun, matching)
+						}
 					})
 				}
+			}
 
-				for _, test := range testsToRun.Apply(allTests) {
-					log := log.With(slog.String("test", test.GetMetadata().GetUid()))
-
-					switch testType := test.TestType.(type) {
-					case *TestCase_Skip:
-						log.Debug("Test skipped", "reason", testType.Skip.Message)
-						continue
-					case *TestCase_Invalid:
-						log.Error("Invalid test", "reason", testType.Invalid.Message)
-						return fmt.Errorf("invalid test, message: %s", testType.Invalid.Message)
-
-					case *TestCase_Eval:
-						log.Debug("Running test")
-						out, err := executeTest(specctx.WithLogger(ctx, log), test, variant)
-						if err != nil {
-							return err
-						}
-
-						result, err := runtime.EvaluateTestResult(ctx, test, out)
-						if err != nil {
-							return fmt.Errorf("couldn't evaluate result: %w", err)
-						}
+			for _, test := range testsToRun {
+				log := log.With(slog.String("test", test.GetMetadata().GetUid()))
 
-						log.Info("Completed evaluation", "result", result)
+				attempts := runAttempts(specctx.WithLogger(ctx, log), runtime, test, variant, opts)
+				result := applyDeviation(summarizeAttempts(attempts), deviations.Find(test))
+				log.Info("Completed evaluation", "result", result, "attempts", len(attempts))
 
-					default:
-						return fmt.Errorf("invalid test type %T", test.TestType)
-					}
+				if opts.OnResult != nil {
+					opts.OnResult(&TestCaseResult{
+						TestUid:           test.GetMetadata().GetUid(),
+						ImplementationUid: impl.GetMetadata().GetUid(),
+						VariantUid:        variant.GetMetadata().GetUid(),
+						Result:            result,
+						Attempts:          attempts,
+					})
 				}
 			}
 		}
@@ -101,6 +130,56 @@ func Execute(ctx context.Context, suite TestSuite, opts ExecutionOptions) error
 	return nil
 }
 
+// runTest runs a single test against the variant, problems running the test
+// are reported as errors in the result.
+func runTest(ctx context.Context, runtime *Runtime, test *TestCase, variant *ImplementationVariant) *TestAttempt {
+	log := specctx.GetLogger(ctx)
+
+	switch testType := test.TestType.(type) {
+	case *TestCase_Skip:
+		log.Debug("Test skipped", "reason", testType.Skip.Message)
+		return &TestAttempt{Result: newSkippedResult(testType.Skip.Message)}
+
+	case *TestCase_Invalid:
+		log.Error("Invalid test", "reason", testType.Invalid.Message)
+		return &TestAttempt{
+			Result: newErrorResult(fmt.Sprintf("invalid test, message: %s", testType.Invalid.Message)),
+		}
+
+	case *TestCase_Eval:
+		log.Debug("Running test")
+		out, err := executeTest(ctx, test, variant)
+		if err != nil {
+			return &TestAttempt{Result: newErrorResult(err.Error())}
+		}
+
+		result, err := runtime.EvaluateTestResult(ctx, test, out)
+		if err != nil {
+			return &TestAttempt{
+				Result: newErrorResult(fmt.Sprintf("couldn't evaluate result: %s", err)),
+				Output: out,
+			}
+		}
+
+		return &TestAttempt{Result: result, Output: out}
+
+	default:
+		return &TestAttempt{Result: newErrorResult(fmt.Sprintf("invalid test type %T", test.TestType))}
+	}
+}
+
+func newSkippedResult(message string) *TestResult {
+	return &TestResult{
+		Status: &TestResult_Skipped_{Skipped: &TestResult_Skipped{Message: message}},
+	}
+}
+
+func newErrorResult(message string) *TestResult {
+	return &TestResult{
+		Status: &TestResult_Error_{Error: &TestResult_Error{Message: message}},
+	}
+}
+
 func executeTest(ctx context.Context, testCase *TestCase, variant *ImplementationVariant) (*ProcessOutput, error) {
 	// For spec, implementation, suite
 	log := specctx.GetLogger(ctx)
@@ -112,19 +191,24 @@ func executeTest(ctx context.Context, testCase *TestCase, variant *Implementatio
 	switch runtime := variant.Runtime.(type) {
 	case *ImplementationVariant_Local:
 
-		tmp, err := os.CreateTemp("", "spec-test")
+		dir, err := os.MkdirTemp("", "spec-test")
 		if err != nil {
 			return nil, err
 		}
-		defer os.Remove(tmp.Name())
+		defer os.RemoveAll(dir)
 
 		program := testCase.GetEval().GetInput()
+		programPath := filepath.Join(dir, ProgramFileName)
 
-		if _, err := tmp.Write([]byte(program)); err != nil {
-			return nil, fmt.Errorf("couldn't write test to file %q: %w", tmp.Name(), err)
+		if err := os.WriteFile(programPath, []byte(program), 0600); err != nil {
+			return nil, fmt.Errorf("couldn't write test to file %q: %w", programPath, err)
 		}
 
-		cmd := formatEvalCommand(variant.GetTestCommand(), program, tmp.Name())
+		if err := writeTestFiles(dir, testCase.GetEval().GetFiles()); err != nil {
+			return nil, err
+		}
+
+		cmd := formatEvalCommand(variant.GetTestCommand(), program, programPath, dir)
 		if len(cmd) == 0 {
 			return nil, errors.New("can't execute an empty command")
 		}
@@ -161,8 +245,31 @@ func executeTest(ctx context.Context, testCase *TestCase, variant *Implementatio
 	}
 }
 
-func formatEvalCommand(cmd []string, program, programPath string) []string {
-	replacer := strings.NewReplacer("$(PROGRAM)", program, "$(PROGRAM_PATH)", programPath)
+// writeTestFiles writes the files a test needs into the program's directory.
+func writeTestFiles(dir string, files []*TestFile) error {
+	for _, file := range files {
+		name := file.GetName()
+		if !fs.ValidPath(name) || name == "." || ConflictsWithProgram(name) {
+			return fmt.Errorf("invalid test file name %q", name)
+		}
+		if file.GetPath() != "" {
+			return fmt.Errorf("test file %q wasn't read from %q", name, file.GetPath())
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return fmt.Errorf("couldn't create directory for test file %q: %w", name, err)
+		}
+		if err := os.WriteFile(path, []byte(file.GetContent()), 0600); err != nil {
+			return fmt.Errorf("couldn't write test file %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func formatEvalCommand(cmd []string, program, programPath, programDir string) []string {
+	replacer := strings.NewReplacer("$(PROGRAM)", program, "$(PROGRAM_PATH)", programPath, "$(PROGRAM_DIR)", programDir)
 
 	var replaced []string
 	for _, part := range cmd {
@@ -172,14 +279,40 @@ func formatEvalCommand(cmd []string, program, programPath string) []string {
 	return replaced
 }
 
-func getTestFilters(sections []*SpecificationSection) ([]Filter[*TestCase], error) {
+// getTestFilters returns filters for the tests of each section matching the
+// section filter, all sections match if selected is set.
+// ApplicableTests returns the tests the specification's exclusion selector
+// doesn't match.
+func (spec *Specification) ApplicableTests(tests []*TestCase) ([]*TestCase, error) {
+	if spec.GetExclusionTestSelector() == "" {
+		return tests, nil
+	}
+
+	exclusion, err := labels.Parse(spec.GetExclusionTestSelector())
+	if err != nil {
+		return nil, fmt.Errorf("specification %q has invalid exclusion selector: %w", spec.GetMetadata().GetUid(), err)
+	}
+
+	var out []*TestCase
+	for _, test := range tests {
+		if !exclusion.Matches(labels.Set(test.GetMetadata().GetLabels())) {
+			out = append(out, test)
+		}
+	}
+	return out, nil
+}
+
+func getTestFilters(sections []*SpecificationSection, sectionFilter Filter[*SpecificationSection], selected bool) ([]Filter[*TestCase], error) {
 	var filters []Filter[*TestCase]
 
 	for _, section := range sections {
+		metadata := section.GetMetadata()
+		sectionSelected := selected || sectionFilter.Matches(metadata.GetUid(), metadata.GetLabels())
+
 		switch content := section.Content.(type) {
 		case *SpecificationSection_SectionSummary:
 
-			subsectionFilters, err := getTestFilters(content.SectionSummary.GetSubsections())
+			subsectionFilters, err := getTestFilters(content.SectionSummary.GetSubsections(), sectionFilter, sectionSelected)
 			if err != nil {
 				return nil, err
 			}
@@ -187,6 +320,10 @@ func getTestFilters(sections []*SpecificationSection) ([]Filter[*TestCase], erro
 			filters = append(filters, subsectionFilters...)
 
 		case *SpecificationSection_TestSummary:
+			if !sectionSelected {
+				continue
+			}
+
 			testSelector, err := labels.Parse(content.TestSummary.TestSelector)
 			if err != nil {
 				return nil, err
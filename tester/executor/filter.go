@@ -3,14 +3,18 @@ package executor
 import "k8s.io/apimachinery/pkg/labels"
 
 // Filter defines criteria to match elements by.
-// Criteria are a union.
+//
+// Values within a single criterion are a union, e.g. an item matches if its UID
+// is any of the supplied UIDs. Criteria are an intersection, e.g. an item must
+// match both the UIDs and the label selectors if both are supplied. Criteria that
+// were never supplied match everything.
 type Filter[T interface {
 	GetMetadata() *Metadata
 }] struct {
-	// Uids defines a set of unique identifiers to match.
+	// Uids defines a set of unique identifiers to match, nil matches all.
 	uids map[string]any
-	// LabelSelector defines a selector to match items by labels.
-	labelSelector labels.Selector
+	// LabelSelectors defines selectors to match items by labels, nil matches all.
+	labelSelectors []labels.Selector
 }
 
 // NewFilter initializes a new filter that matches everything.
@@ -18,12 +22,15 @@ func NewFilter[T interface {
 	GetMetadata() *Metadata
 }]() Filter[T] {
 	return Filter[T]{
-		uids:          nil,
-		labelSelector: nil,
+		uids:           nil,
+		labelSelectors: nil,
 	}
 }
 
-// Adds a new UID to the filter.
+// WithUid creates a union between the existing UIDs and the new.
+//
+// Calling WithUid with no UIDs on a filter without a UID criterion produces a
+// filter that matches nothing.
 func (f Filter[T]) WithUid(uids ...string) Filter[T] {
 	out := Filter[T]{}
 	out.uids = make(map[string]any)
@@ -35,38 +42,40 @@ func (f Filter[T]) WithUid(uids ...string) Filter[T] {
 		out.uids[k] = true
 	}
 
-	out.labelSelector = f.labelSelector
+	out.labelSelectors = f.labelSelectors
 
 	return out
 }
 
-// WithSelector creates a union between the existing selector and the new.
+// WithSelector creates a union between the existing selectors and the new.
 func (f Filter[T]) WithSelector(selector labels.Selector) Filter[T] {
 	out := Filter[T]{}
 	out.uids = f.uids
 
-	if f.labelSelector == nil {
-		out.labelSelector = selector
-	} else {
-		newRequirements, _ := selector.Requirements()
-		out.labelSelector = f.labelSelector.Add(newRequirements...)
-	}
+	out.labelSelectors = append(out.labelSelectors, f.labelSelectors...)
+	out.labelSelectors = append(out.labelSelectors, selector)
 
 	return out
 }
 
-// Matches checks if the selector matches all supplied filter criteria.
+// Matches checks if the item matches all supplied filter criteria.
 func (f Filter[T]) Matches(itemUid string, itemLabels map[string]string) bool {
-	if len(f.uids) != 0 {
+	if f.uids != nil {
 		if _, ok := f.uids[itemUid]; !ok {
-			return true
+			return false
 		}
 	}
 
-	if f.labelSelector == nil || f.labelSelector.Matches(labels.Set(itemLabels)) {
+	if f.labelSelectors == nil {
 		return true
 	}
 
+	for _, selector := range f.labelSelectors {
+		if selector.Matches(labels.Set(itemLabels)) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -87,6 +96,4 @@ func (f Filter[T]) ForEach(items []T, callback func(T)) {
 			callback(item)
 		}
 	}
-
-	return
 }
@@ -0,0 +1,234 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func mustParseSelector(t *testing.T, selector string) labels.Selector {
+	t.Helper()
+
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		t.Fatalf("couldn't parse selector %q: %v", selector, err)
+	}
+
+	return parsed
+}
+
+func TestFilter_Matches(t *testing.T) {
+	cases := map[string]struct {
+		filter func(t *testing.T) Filter[*TestCase]
+		uid    string
+		labels map[string]string
+		want   bool
+	}{
+		"empty filter matches everything": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]() },
+			uid:    "a",
+			want:   true,
+		},
+		"uid in set": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]().WithUid("a", "b") },
+			uid:    "b",
+			want:   true,
+		},
+		"uid not in set": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]().WithUid("a", "b") },
+			uid:    "c",
+			want:   false,
+		},
+		"uids are a union across calls": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]().WithUid("a").WithUid("b") },
+			uid:    "a",
+			want:   true,
+		},
+		"empty uid set matches nothing": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]().WithUid() },
+			uid:    "a",
+			want:   false,
+		},
+		"selector matches": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithSelector(mustParseSelector(t, "lang=scheme"))
+			},
+			uid:    "a",
+			labels: map[string]string{"lang": "scheme"},
+			want:   true,
+		},
+		"selector doesn't match": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithSelector(mustParseSelector(t, "lang=scheme"))
+			},
+			uid:    "a",
+			labels: map[string]string{"lang": "lisp"},
+			want:   false,
+		},
+		"selectors are a union": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().
+					WithSelector(mustParseSelector(t, "lang=scheme")).
+					WithSelector(mustParseSelector(t, "lang=lisp"))
+			},
+			uid:    "a",
+			labels: map[string]string{"lang": "lisp"},
+			want:   true,
+		},
+		"uid and selector are an intersection, both match": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithUid("a").WithSelector(mustParseSelector(t, "lang=scheme"))
+			},
+			uid:    "a",
+			labels: map[string]string{"lang": "scheme"},
+			want:   true,
+		},
+		"uid and selector are an intersection, only uid matches": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithUid("a").WithSelector(mustParseSelector(t, "lang=scheme"))
+			},
+			uid:    "a",
+			labels: map[string]string{"lang": "lisp"},
+			want:   false,
+		},
+		"uid and selector are an intersection, only selector matches": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithSelector(mustParseSelector(t, "lang=scheme")).WithUid("a")
+			},
+			uid:    "b",
+			labels: map[string]string{"lang": "scheme"},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.filter(t).Matches(tc.uid, tc.labels)
+			if got != tc.want {
+				t.Errorf("Matches(%q, %v) = %v, want %v", tc.uid, tc.labels, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	items := []*TestCase{
+		{Metadata: &Metadata{Uid: "a", Labels: map[string]string{"lang": "scheme"}}},
+		{Metadata: &Metadata{Uid: "b", Labels: map[string]string{"lang": "lisp"}}},
+		{Metadata: &Metadata{Uid: "c", Labels: map[string]string{"lang": "scheme"}}},
+	}
+
+	cases := map[string]struct {
+		filter func(t *testing.T) Filter[*TestCase]
+		want   []string
+	}{
+		"everything": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]() },
+			want:   []string{"a", "b", "c"},
+		},
+		"by uid": {
+			filter: func(t *testing.T) Filter[*TestCase] { return NewFilter[*TestCase]().WithUid("c", "a") },
+			want:   []string{"a", "c"},
+		},
+		"by selector": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithSelector(mustParseSelector(t, "lang=lisp"))
+			},
+			want: []string{"b"},
+		},
+		"by uid and selector": {
+			filter: func(t *testing.T) Filter[*TestCase] {
+				return NewFilter[*TestCase]().WithUid("a", "b").WithSelector(mustParseSelector(t, "lang=scheme"))
+			},
+			want: []string{"a"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, item := range tc.filter(t).Apply(items) {
+				got = append(got, item.GetMetadata().GetUid())
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Apply() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTestFilters(t *testing.T) {
+	leaf := func(uid, selector string) *SpecificationSection {
+		return &SpecificationSection{
+			Metadata: &Metadata{Uid: uid},
+			Content: &SpecificationSection_TestSummary{
+				TestSummary: &SpecificationTestSummary{TestSelector: selector},
+			},
+		}
+	}
+
+	sections := []*SpecificationSection{
+		{
+			Metadata: &Metadata{Uid: "core"},
+			Content: &SpecificationSection_SectionSummary{
+				SectionSummary: &SpecificationSectionSummary{
+					Subsections: []*SpecificationSection{
+						leaf("booleans", "kind=boolean"),
+						leaf("numbers", "kind=number"),
+					},
+				},
+			},
+		},
+		leaf("macros", "kind=macro"),
+	}
+
+	tests := []*TestCase{
+		{Metadata: &Metadata{Uid: "bool", Labels: map[string]string{"kind": "boolean"}}},
+		{Metadata: &Metadata{Uid: "num", Labels: map[string]string{"kind": "number"}}},
+		{Metadata: &Metadata{Uid: "macro", Labels: map[string]string{"kind": "macro"}}},
+	}
+
+	cases := map[string]struct {
+		sectionFilter Filter[*SpecificationSection]
+		want          []string
+	}{
+		"all sections": {
+			sectionFilter: NewFilter[*SpecificationSection](),
+			want:          []string{"bool", "num", "macro"},
+		},
+		"leaf section": {
+			sectionFilter: NewFilter[*SpecificationSection]().WithUid("numbers"),
+			want:          []string{"num"},
+		},
+		"parent section includes children": {
+			sectionFilter: NewFilter[*SpecificationSection]().WithUid("core"),
+			want:          []string{"bool", "num"},
+		},
+		"multiple sections": {
+			sectionFilter: NewFilter[*SpecificationSection]().WithUid("booleans", "macros"),
+			want:          []string{"bool", "macro"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			filters, err := getTestFilters(sections, tc.sectionFilter, false)
+			if err != nil {
+				t.Fatalf("getTestFilters() error = %v", err)
+			}
+
+			var got []string
+			for _, filter := range filters {
+				filter.ForEach(tests, func(test *TestCase) {
+					got = append(got, test.GetMetadata().GetUid())
+				})
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("getTestFilters() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
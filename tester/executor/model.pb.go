@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.27.1
-// 	protoc        v3.9.1
+// 	protoc        v4.22.3
 // source: model.proto
 
 package executor
@@ -252,9 +252,18 @@ type EvalTest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Program given to the implementation, including any prelude and epilogue.
 	Input                  string `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
 	ExpectationType        string `protobuf:"bytes,2,opt,name=expectation_type,json=expectationType,proto3" json:"expectation_type,omitempty"`
 	ExpectationOptionsJson string `protobuf:"bytes,3,opt,name=expectation_options_json,json=expectationOptionsJson,proto3" json:"expectation_options_json,omitempty"`
+	// Number of times to retry the test if it doesn't pass, overrides the
+	// execution default when set. A test that passes on retry is reported as
+	// flaky.
+	Retries *int32 `protobuf:"varint,4,opt,name=retries,proto3,oneof" json:"retries,omitempty"`
+	// Input of the test case before the prelude and epilogue were added.
+	RawInput string `protobuf:"bytes,5,opt,name=raw_input,json=rawInput,proto3" json:"raw_input,omitempty"`
+	// Files written next to the program before it's run.
+	Files []*TestFile `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
 }
 
 func (x *EvalTest) Reset() {
@@ -310,6 +319,95 @@ func (x *EvalTest) GetExpectationOptionsJson() string {
 	return ""
 }
 
+func (x *EvalTest) GetRetries() int32 {
+	if x != nil && x.Retries != nil {
+		return *x.Retries
+	}
+	return 0
+}
+
+func (x *EvalTest) GetRawInput() string {
+	if x != nil {
+		return x.RawInput
+	}
+	return ""
+}
+
+func (x *EvalTest) GetFiles() []*TestFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// A file a test needs next to its program.
+type TestFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Slash separated path of the file relative to the program's directory.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Content of the file.
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Path to read the content from relative to the test's definition, cleared
+	// once the content is read when the suite is loaded.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *TestFile) Reset() {
+	*x = TestFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestFile) ProtoMessage() {}
+
+func (x *TestFile) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestFile.ProtoReflect.Descriptor instead.
+func (*TestFile) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TestFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestFile) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *TestFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
 // A test that will always fail.
 type InvalidTest struct {
 	state         protoimpl.MessageState
@@ -323,7 +421,7 @@ type InvalidTest struct {
 func (x *InvalidTest) Reset() {
 	*x = InvalidTest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[4]
+		mi := &file_model_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -336,7 +434,7 @@ func (x *InvalidTest) String() string {
 func (*InvalidTest) ProtoMessage() {}
 
 func (x *InvalidTest) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[4]
+	mi := &file_model_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +447,7 @@ func (x *InvalidTest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvalidTest.ProtoReflect.Descriptor instead.
 func (*InvalidTest) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{4}
+	return file_model_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *InvalidTest) GetMessage() string {
@@ -371,7 +469,7 @@ type Implementation struct {
 func (x *Implementation) Reset() {
 	*x = Implementation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[5]
+		mi := &file_model_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -384,7 +482,7 @@ func (x *Implementation) String() string {
 func (*Implementation) ProtoMessage() {}
 
 func (x *Implementation) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[5]
+	mi := &file_model_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +495,7 @@ func (x *Implementation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Implementation.ProtoReflect.Descriptor instead.
 func (*Implementation) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{5}
+	return file_model_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Implementation) GetMetadata() *Metadata {
@@ -421,17 +519,20 @@ type ImplementationVariant struct {
 
 	Metadata          *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	SpecificationUids []string  `protobuf:"bytes,2,rep,name=specification_uids,json=specificationUids,proto3" json:"specification_uids,omitempty"`
-	// Command to run for tests, $(PROGRAM) and $(PROGRAM_PATH) will be replaced.
+	// Command to run for tests, $(PROGRAM), $(PROGRAM_PATH) and $(PROGRAM_DIR)
+	// will be replaced.
 	TestCommand []string `protobuf:"bytes,3,rep,name=test_command,json=testCommand,proto3" json:"test_command,omitempty"`
 	// Types that are assignable to Runtime:
 	//	*ImplementationVariant_Local
 	Runtime isImplementationVariant_Runtime `protobuf_oneof:"runtime"`
+	// Known deviations from the specifications.
+	Deviations []*Deviation `protobuf:"bytes,5,rep,name=deviations,proto3" json:"deviations,omitempty"`
 }
 
 func (x *ImplementationVariant) Reset() {
 	*x = ImplementationVariant{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[6]
+		mi := &file_model_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -444,7 +545,7 @@ func (x *ImplementationVariant) String() string {
 func (*ImplementationVariant) ProtoMessage() {}
 
 func (x *ImplementationVariant) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[6]
+	mi := &file_model_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +558,7 @@ func (x *ImplementationVariant) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImplementationVariant.ProtoReflect.Descriptor instead.
 func (*ImplementationVariant) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{6}
+	return file_model_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ImplementationVariant) GetMetadata() *Metadata {
@@ -495,6 +596,13 @@ func (x *ImplementationVariant) GetLocal() *ImplementationRuntimeLocal {
 	return nil
 }
 
+func (x *ImplementationVariant) GetDeviations() []*Deviation {
+	if x != nil {
+		return x.Deviations
+	}
+	return nil
+}
+
 type isImplementationVariant_Runtime interface {
 	isImplementationVariant_Runtime()
 }
@@ -505,6 +613,82 @@ type ImplementationVariant_Local struct {
 
 func (*ImplementationVariant_Local) isImplementationVariant_Runtime() {}
 
+// A documented difference between an implementation and its specifications.
+type Deviation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UIDs of the tests the deviation applies to.
+	TestUids []string `protobuf:"bytes,1,rep,name=test_uids,json=testUids,proto3" json:"test_uids,omitempty"`
+	// Label selector for the tests the deviation applies to.
+	TestSelector string `protobuf:"bytes,2,opt,name=test_selector,json=testSelector,proto3" json:"test_selector,omitempty"`
+	// Reason the implementation deviates.
+	Justification string `protobuf:"bytes,3,opt,name=justification,proto3" json:"justification,omitempty"`
+	// Optional link to an upstream bug for the deviation.
+	BugUrl string `protobuf:"bytes,4,opt,name=bug_url,json=bugUrl,proto3" json:"bug_url,omitempty"`
+}
+
+func (x *Deviation) Reset() {
+	*x = Deviation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Deviation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deviation) ProtoMessage() {}
+
+func (x *Deviation) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deviation.ProtoReflect.Descriptor instead.
+func (*Deviation) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Deviation) GetTestUids() []string {
+	if x != nil {
+		return x.TestUids
+	}
+	return nil
+}
+
+func (x *Deviation) GetTestSelector() string {
+	if x != nil {
+		return x.TestSelector
+	}
+	return ""
+}
+
+func (x *Deviation) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *Deviation) GetBugUrl() string {
+	if x != nil {
+		return x.BugUrl
+	}
+	return ""
+}
+
 type ImplementationRuntimeLocal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -514,7 +698,7 @@ type ImplementationRuntimeLocal struct {
 func (x *ImplementationRuntimeLocal) Reset() {
 	*x = ImplementationRuntimeLocal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[7]
+		mi := &file_model_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -527,7 +711,7 @@ func (x *ImplementationRuntimeLocal) String() string {
 func (*ImplementationRuntimeLocal) ProtoMessage() {}
 
 func (x *ImplementationRuntimeLocal) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[7]
+	mi := &file_model_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +724,7 @@ func (x *ImplementationRuntimeLocal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImplementationRuntimeLocal.ProtoReflect.Descriptor instead.
 func (*ImplementationRuntimeLocal) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{7}
+	return file_model_proto_rawDescGZIP(), []int{9}
 }
 
 type Specification struct {
@@ -551,12 +735,15 @@ type Specification struct {
 	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	// Sections that make up this specification.
 	Sections []*SpecificationSection `protobuf:"bytes,2,rep,name=sections,proto3" json:"sections,omitempty"`
+	// Label selector for tests that never apply to the specification, even if
+	// a section selects them.
+	ExclusionTestSelector string `protobuf:"bytes,3,opt,name=exclusion_test_selector,json=exclusionTestSelector,proto3" json:"exclusion_test_selector,omitempty"`
 }
 
 func (x *Specification) Reset() {
 	*x = Specification{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[8]
+		mi := &file_model_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -569,7 +756,7 @@ func (x *Specification) String() string {
 func (*Specification) ProtoMessage() {}
 
 func (x *Specification) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[8]
+	mi := &file_model_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +769,7 @@ func (x *Specification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Specification.ProtoReflect.Descriptor instead.
 func (*Specification) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{8}
+	return file_model_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Specification) GetMetadata() *Metadata {
@@ -599,6 +786,13 @@ func (x *Specification) GetSections() []*SpecificationSection {
 	return nil
 }
 
+func (x *Specification) GetExclusionTestSelector() string {
+	if x != nil {
+		return x.ExclusionTestSelector
+	}
+	return ""
+}
+
 type SpecificationSection struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -614,7 +808,7 @@ type SpecificationSection struct {
 func (x *SpecificationSection) Reset() {
 	*x = SpecificationSection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[9]
+		mi := &file_model_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -627,7 +821,7 @@ func (x *SpecificationSection) String() string {
 func (*SpecificationSection) ProtoMessage() {}
 
 func (x *SpecificationSection) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[9]
+	mi := &file_model_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -640,7 +834,7 @@ func (x *SpecificationSection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecificationSection.ProtoReflect.Descriptor instead.
 func (*SpecificationSection) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{9}
+	return file_model_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SpecificationSection) GetMetadata() *Metadata {
@@ -693,12 +887,14 @@ type SpecificationSectionSummary struct {
 	unknownFields protoimpl.UnknownFields
 
 	Subsections []*SpecificationSection `protobuf:"bytes,1,rep,name=subsections,proto3" json:"subsections,omitempty"`
+	// Whether this section's subsections are optional.
+	Optional bool `protobuf:"varint,2,opt,name=optional,proto3" json:"optional,omitempty"`
 }
 
 func (x *SpecificationSectionSummary) Reset() {
 	*x = SpecificationSectionSummary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[10]
+		mi := &file_model_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -711,7 +907,7 @@ func (x *SpecificationSectionSummary) String() string {
 func (*SpecificationSectionSummary) ProtoMessage() {}
 
 func (x *SpecificationSectionSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[10]
+	mi := &file_model_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +920,7 @@ func (x *SpecificationSectionSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecificationSectionSummary.ProtoReflect.Descriptor instead.
 func (*SpecificationSectionSummary) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{10}
+	return file_model_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SpecificationSectionSummary) GetSubsections() []*SpecificationSection {
@@ -734,6 +930,13 @@ func (x *SpecificationSectionSummary) GetSubsections() []*SpecificationSection {
 	return nil
 }
 
+func (x *SpecificationSectionSummary) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
 type SpecificationTestSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -748,7 +951,7 @@ type SpecificationTestSummary struct {
 func (x *SpecificationTestSummary) Reset() {
 	*x = SpecificationTestSummary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[11]
+		mi := &file_model_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -761,7 +964,7 @@ func (x *SpecificationTestSummary) String() string {
 func (*SpecificationTestSummary) ProtoMessage() {}
 
 func (x *SpecificationTestSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[11]
+	mi := &file_model_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +977,7 @@ func (x *SpecificationTestSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecificationTestSummary.ProtoReflect.Descriptor instead.
 func (*SpecificationTestSummary) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{11}
+	return file_model_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SpecificationTestSummary) GetTestSelector() string {
@@ -804,7 +1007,7 @@ type ProcessOutput struct {
 func (x *ProcessOutput) Reset() {
 	*x = ProcessOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[12]
+		mi := &file_model_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -817,7 +1020,7 @@ func (x *ProcessOutput) String() string {
 func (*ProcessOutput) ProtoMessage() {}
 
 func (x *ProcessOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[12]
+	mi := &file_model_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,7 +1033,7 @@ func (x *ProcessOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessOutput.ProtoReflect.Descriptor instead.
 func (*ProcessOutput) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{12}
+	return file_model_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ProcessOutput) GetStdout() string {
@@ -863,13 +1066,18 @@ type TestResult struct {
 	//	*TestResult_Success_
 	//	*TestResult_Failure_
 	//	*TestResult_Example
+	//	*TestResult_Skipped_
+	//	*TestResult_Error_
+	//	*TestResult_ExpectedFailure_
+	//	*TestResult_UnexpectedPass_
+	//	*TestResult_Flaky_
 	Status isTestResult_Status `protobuf_oneof:"status"`
 }
 
 func (x *TestResult) Reset() {
 	*x = TestResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[13]
+		mi := &file_model_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -882,7 +1090,7 @@ func (x *TestResult) String() string {
 func (*TestResult) ProtoMessage() {}
 
 func (x *TestResult) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[13]
+	mi := &file_model_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,7 +1103,7 @@ func (x *TestResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestResult.ProtoReflect.Descriptor instead.
 func (*TestResult) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{13}
+	return file_model_proto_rawDescGZIP(), []int{15}
 }
 
 func (m *TestResult) GetStatus() isTestResult_Status {
@@ -926,6 +1134,41 @@ func (x *TestResult) GetExample() *ProcessOutput {
 	return nil
 }
 
+func (x *TestResult) GetSkipped() *TestResult_Skipped {
+	if x, ok := x.GetStatus().(*TestResult_Skipped_); ok {
+		return x.Skipped
+	}
+	return nil
+}
+
+func (x *TestResult) GetError() *TestResult_Error {
+	if x, ok := x.GetStatus().(*TestResult_Error_); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *TestResult) GetExpectedFailure() *TestResult_ExpectedFailure {
+	if x, ok := x.GetStatus().(*TestResult_ExpectedFailure_); ok {
+		return x.ExpectedFailure
+	}
+	return nil
+}
+
+func (x *TestResult) GetUnexpectedPass() *TestResult_UnexpectedPass {
+	if x, ok := x.GetStatus().(*TestResult_UnexpectedPass_); ok {
+		return x.UnexpectedPass
+	}
+	return nil
+}
+
+func (x *TestResult) GetFlaky() *TestResult_Flaky {
+	if x, ok := x.GetStatus().(*TestResult_Flaky_); ok {
+		return x.Flaky
+	}
+	return nil
+}
+
 type isTestResult_Status interface {
 	isTestResult_Status()
 }
@@ -942,35 +1185,70 @@ type TestResult_Example struct {
 	Example *ProcessOutput `protobuf:"bytes,3,opt,name=example,proto3,oneof"`
 }
 
+type TestResult_Skipped_ struct {
+	Skipped *TestResult_Skipped `protobuf:"bytes,4,opt,name=skipped,proto3,oneof"`
+}
+
+type TestResult_Error_ struct {
+	Error *TestResult_Error `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type TestResult_ExpectedFailure_ struct {
+	ExpectedFailure *TestResult_ExpectedFailure `protobuf:"bytes,6,opt,name=expected_failure,json=expectedFailure,proto3,oneof"`
+}
+
+type TestResult_UnexpectedPass_ struct {
+	UnexpectedPass *TestResult_UnexpectedPass `protobuf:"bytes,7,opt,name=unexpected_pass,json=unexpectedPass,proto3,oneof"`
+}
+
+type TestResult_Flaky_ struct {
+	Flaky *TestResult_Flaky `protobuf:"bytes,8,opt,name=flaky,proto3,oneof"`
+}
+
 func (*TestResult_Success_) isTestResult_Status() {}
 
 func (*TestResult_Failure_) isTestResult_Status() {}
 
 func (*TestResult_Example) isTestResult_Status() {}
 
-type TestResult_Success struct {
+func (*TestResult_Skipped_) isTestResult_Status() {}
+
+func (*TestResult_Error_) isTestResult_Status() {}
+
+func (*TestResult_ExpectedFailure_) isTestResult_Status() {}
+
+func (*TestResult_UnexpectedPass_) isTestResult_Status() {}
+
+func (*TestResult_Flaky_) isTestResult_Status() {}
+
+// A single attempt at running a test.
+type TestAttempt struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Result *TestResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// Output of the process, unset if the test didn't run.
+	Output *ProcessOutput `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
 }
 
-func (x *TestResult_Success) Reset() {
-	*x = TestResult_Success{}
+func (x *TestAttempt) Reset() {
+	*x = TestAttempt{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[15]
+		mi := &file_model_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TestResult_Success) String() string {
+func (x *TestAttempt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestResult_Success) ProtoMessage() {}
+func (*TestAttempt) ProtoMessage() {}
 
-func (x *TestResult_Success) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[15]
+func (x *TestAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -981,36 +1259,56 @@ func (x *TestResult_Success) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestResult_Success.ProtoReflect.Descriptor instead.
-func (*TestResult_Success) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{13, 0}
+// Deprecated: Use TestAttempt.ProtoReflect.Descriptor instead.
+func (*TestAttempt) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{16}
 }
 
-type TestResult_Failure struct {
+func (x *TestAttempt) GetResult() *TestResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TestAttempt) GetOutput() *ProcessOutput {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+// The result of running a single test case against an implementation variant.
+type TestCaseResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TestUid           string      `protobuf:"bytes,1,opt,name=test_uid,json=testUid,proto3" json:"test_uid,omitempty"`
+	ImplementationUid string      `protobuf:"bytes,2,opt,name=implementation_uid,json=implementationUid,proto3" json:"implementation_uid,omitempty"`
+	VariantUid        string      `protobuf:"bytes,3,opt,name=variant_uid,json=variantUid,proto3" json:"variant_uid,omitempty"`
+	Result            *TestResult `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	// Every attempt made to run the test.
+	Attempts []*TestAttempt `protobuf:"bytes,5,rep,name=attempts,proto3" json:"attempts,omitempty"`
 }
 
-func (x *TestResult_Failure) Reset() {
-	*x = TestResult_Failure{}
+func (x *TestCaseResult) Reset() {
+	*x = TestCaseResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[16]
+		mi := &file_model_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TestResult_Failure) String() string {
+func (x *TestCaseResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestResult_Failure) ProtoMessage() {}
+func (*TestCaseResult) ProtoMessage() {}
 
-func (x *TestResult_Failure) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[16]
+func (x *TestCaseResult) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1021,18 +1319,631 @@ func (x *TestResult_Failure) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestResult_Failure.ProtoReflect.Descriptor instead.
-func (*TestResult_Failure) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{13, 1}
+// Deprecated: Use TestCaseResult.ProtoReflect.Descriptor instead.
+func (*TestCaseResult) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *TestResult_Failure) GetMessage() string {
+func (x *TestCaseResult) GetTestUid() string {
 	if x != nil {
-		return x.Message
+		return x.TestUid
 	}
 	return ""
 }
 
+func (x *TestCaseResult) GetImplementationUid() string {
+	if x != nil {
+		return x.ImplementationUid
+	}
+	return ""
+}
+
+func (x *TestCaseResult) GetVariantUid() string {
+	if x != nil {
+		return x.VariantUid
+	}
+	return ""
+}
+
+func (x *TestCaseResult) GetResult() *TestResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TestCaseResult) GetAttempts() []*TestAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+// A stored set of test results.
+type ResultSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*TestCaseResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *ResultSet) Reset() {
+	*x = ResultSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultSet) ProtoMessage() {}
+
+func (x *ResultSet) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultSet.ProtoReflect.Descriptor instead.
+func (*ResultSet) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ResultSet) GetResults() []*TestCaseResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Configuration for the assertion runtime.
+type AssertionConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Script to evaluate assertion functions within.
+	Script      string                 `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Definitions []*AssertionDefinition `protobuf:"bytes,2,rep,name=definitions,proto3" json:"definitions,omitempty"`
+}
+
+func (x *AssertionConfig) Reset() {
+	*x = AssertionConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssertionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssertionConfig) ProtoMessage() {}
+
+func (x *AssertionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssertionConfig.ProtoReflect.Descriptor instead.
+func (*AssertionConfig) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AssertionConfig) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *AssertionConfig) GetDefinitions() []*AssertionDefinition {
+	if x != nil {
+		return x.Definitions
+	}
+	return nil
+}
+
+// An expectation type tests can use.
+type AssertionDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Function in the script that evaluates the expectation.
+	FunctionName string `protobuf:"bytes,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// JSON schema the expectation's options must match.
+	InputSchemaJson string `protobuf:"bytes,3,opt,name=input_schema_json,json=inputSchemaJson,proto3" json:"input_schema_json,omitempty"`
+}
+
+func (x *AssertionDefinition) Reset() {
+	*x = AssertionDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssertionDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssertionDefinition) ProtoMessage() {}
+
+func (x *AssertionDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssertionDefinition.ProtoReflect.Descriptor instead.
+func (*AssertionDefinition) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AssertionDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssertionDefinition) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+func (x *AssertionDefinition) GetInputSchemaJson() string {
+	if x != nil {
+		return x.InputSchemaJson
+	}
+	return ""
+}
+
+// A self-contained set of hydrated tests, specifications and implementations.
+type Bundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata        *Metadata         `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	AssertionConfig *AssertionConfig  `protobuf:"bytes,2,opt,name=assertion_config,json=assertionConfig,proto3" json:"assertion_config,omitempty"`
+	Specifications  []*Specification  `protobuf:"bytes,3,rep,name=specifications,proto3" json:"specifications,omitempty"`
+	Implementations []*Implementation `protobuf:"bytes,4,rep,name=implementations,proto3" json:"implementations,omitempty"`
+	Tests           []*TestCase       `protobuf:"bytes,5,rep,name=tests,proto3" json:"tests,omitempty"`
+}
+
+func (x *Bundle) Reset() {
+	*x = Bundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bundle) ProtoMessage() {}
+
+func (x *Bundle) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bundle.ProtoReflect.Descriptor instead.
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Bundle) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Bundle) GetAssertionConfig() *AssertionConfig {
+	if x != nil {
+		return x.AssertionConfig
+	}
+	return nil
+}
+
+func (x *Bundle) GetSpecifications() []*Specification {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *Bundle) GetImplementations() []*Implementation {
+	if x != nil {
+		return x.Implementations
+	}
+	return nil
+}
+
+func (x *Bundle) GetTests() []*TestCase {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+type TestResult_Success struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TestResult_Success) Reset() {
+	*x = TestResult_Success{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_Success) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_Success) ProtoMessage() {}
+
+func (x *TestResult_Success) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_Success.ProtoReflect.Descriptor instead.
+func (*TestResult_Success) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type TestResult_Failure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *TestResult_Failure) Reset() {
+	*x = TestResult_Failure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_Failure) ProtoMessage() {}
+
+func (x *TestResult_Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_Failure.ProtoReflect.Descriptor instead.
+func (*TestResult_Failure) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 1}
+}
+
+func (x *TestResult_Failure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// The test wasn't run.
+type TestResult_Skipped struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *TestResult_Skipped) Reset() {
+	*x = TestResult_Skipped{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_Skipped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_Skipped) ProtoMessage() {}
+
+func (x *TestResult_Skipped) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_Skipped.ProtoReflect.Descriptor instead.
+func (*TestResult_Skipped) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 2}
+}
+
+func (x *TestResult_Skipped) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// The test couldn't be run or evaluated.
+type TestResult_Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *TestResult_Error) Reset() {
+	*x = TestResult_Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_Error) ProtoMessage() {}
+
+func (x *TestResult_Error) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_Error.ProtoReflect.Descriptor instead.
+func (*TestResult_Error) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 3}
+}
+
+func (x *TestResult_Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// The test failed and is covered by a documented deviation.
+type TestResult_ExpectedFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message   string     `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Deviation *Deviation `protobuf:"bytes,2,opt,name=deviation,proto3" json:"deviation,omitempty"`
+}
+
+func (x *TestResult_ExpectedFailure) Reset() {
+	*x = TestResult_ExpectedFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_ExpectedFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_ExpectedFailure) ProtoMessage() {}
+
+func (x *TestResult_ExpectedFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_ExpectedFailure.ProtoReflect.Descriptor instead.
+func (*TestResult_ExpectedFailure) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 4}
+}
+
+func (x *TestResult_ExpectedFailure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TestResult_ExpectedFailure) GetDeviation() *Deviation {
+	if x != nil {
+		return x.Deviation
+	}
+	return nil
+}
+
+// The test passed despite being covered by a documented deviation.
+type TestResult_UnexpectedPass struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deviation *Deviation `protobuf:"bytes,1,opt,name=deviation,proto3" json:"deviation,omitempty"`
+}
+
+func (x *TestResult_UnexpectedPass) Reset() {
+	*x = TestResult_UnexpectedPass{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_UnexpectedPass) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_UnexpectedPass) ProtoMessage() {}
+
+func (x *TestResult_UnexpectedPass) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_UnexpectedPass.ProtoReflect.Descriptor instead.
+func (*TestResult_UnexpectedPass) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 5}
+}
+
+func (x *TestResult_UnexpectedPass) GetDeviation() *Deviation {
+	if x != nil {
+		return x.Deviation
+	}
+	return nil
+}
+
+// Attempts to run the test disagreed on the outcome.
+type TestResult_Flaky struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Each distinct output produced by the attempts.
+	DistinctOutputs []*ProcessOutput `protobuf:"bytes,1,rep,name=distinct_outputs,json=distinctOutputs,proto3" json:"distinct_outputs,omitempty"`
+}
+
+func (x *TestResult_Flaky) Reset() {
+	*x = TestResult_Flaky{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestResult_Flaky) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult_Flaky) ProtoMessage() {}
+
+func (x *TestResult_Flaky) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult_Flaky.ProtoReflect.Descriptor instead.
+func (*TestResult_Flaky) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{15, 6}
+}
+
+func (x *TestResult_Flaky) GetDistinctOutputs() []*ProcessOutput {
+	if x != nil {
+		return x.DistinctOutputs
+	}
+	return nil
+}
+
 var File_model_proto protoreflect.FileDescriptor
 
 var file_model_proto_rawDesc = []byte{
@@ -1064,7 +1975,7 @@ var file_model_proto_rawDesc = []byte{
 	0x74, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x24, 0x0a, 0x08, 0x53, 0x6b, 0x69,
 	0x70, 0x54, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x85, 0x01, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0xee, 0x01, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
 	0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70,
 	0x75, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78,
@@ -1072,86 +1983,202 @@ var file_model_proto_rawDesc = []byte{
 	0x18, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74,
 	0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x16, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x27, 0x0a, 0x0b, 0x49, 0x6e, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x54, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x6b, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x77, 0x5f, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x61, 0x77, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x22, 0x4c, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x27,
+	0x0a, 0x0b, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x54, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x6b, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6c, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x32, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x6e, 0x74, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x15, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x25,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x11, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x55, 0x69, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x33, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x0a,
+	0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65,
+	0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x09, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x75, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x73, 0x74, 0x55, 0x69, 0x64, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6a, 0x75, 0x73, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x75, 0x67,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x67, 0x55,
+	0x72, 0x6c, 0x22, 0x1c, 0x0a, 0x1a, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x22, 0xa1, 0x01, 0x0a, 0x0d, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x08, 0x76, 0x61, 0x72,
-	0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x49, 0x6d,
-	0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x72, 0x69,
-	0x61, 0x6e, 0x74, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x22, 0xd0, 0x01,
-	0x0a, 0x15, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2d,
-	0x0a, 0x12, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x75, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x70, 0x65, 0x63,
-	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x69, 0x64, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x12, 0x33, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1b, 0x2e, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x05,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
-	0x22, 0x1c, 0x0a, 0x1a, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x22, 0x69,
-	0x0a, 0x0d, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x25, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd1, 0x01, 0x0a, 0x14, 0x53, 0x70,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x70,
 	0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x0f, 0x73, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x48, 0x00, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x12, 0x3e, 0x0a, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x75, 0x6d, 0x6d,
-	0x61, 0x72, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x56, 0x0a,
-	0x1b, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x37, 0x0a, 0x0b,
-	0x73, 0x75, 0x62, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5b, 0x0a, 0x18, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
-	0x79, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x53, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x61, 0x6c, 0x22, 0x5c, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64,
-	0x65, 0x72, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65,
-	0x22, 0xd4, 0x01, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x2f, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x12, 0x2f, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46,
-	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
-	0x65, 0x12, 0x2a, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x48, 0x00, 0x52, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x1a, 0x09, 0x0a,
-	0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x1a, 0x23, 0x0a, 0x07, 0x46, 0x61, 0x69, 0x6c,
-	0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x08, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x6f, 0x73, 0x65, 0x70, 0x68, 0x6c, 0x65, 0x77, 0x69,
-	0x73, 0x34, 0x32, 0x2f, 0x73, 0x70, 0x65, 0x63, 0x2d, 0x74, 0x65, 0x73, 0x74, 0x65, 0x72, 0x2f,
-	0x74, 0x65, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x17,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x22, 0xd1, 0x01, 0x0a, 0x14, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x00, 0x52, 0x0e, 0x73,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x3e, 0x0a,
+	0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x00,
+	0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x09, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x72, 0x0a, 0x1b, 0x53, 0x70, 0x65, 0x63,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x37, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x22, 0x5b, 0x0a, 0x18,
+	0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x73,
+	0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x73, 0x74,
+	0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x74, 0x65, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x22, 0x5c, 0x0a, 0x0d, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f,
+	0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78,
+	0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65,
+	0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x8b, 0x06, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x07, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x48, 0x00, 0x52, 0x07, 0x73, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x48, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x45, 0x0a, 0x0f, 0x75, 0x6e,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x61, 0x73, 0x73, 0x48,
+	0x00, 0x52, 0x0e, 0x75, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x61, 0x73,
+	0x73, 0x12, 0x29, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x6b, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x6c,
+	0x61, 0x6b, 0x79, 0x48, 0x00, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x6b, 0x79, 0x1a, 0x09, 0x0a, 0x07,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x1a, 0x23, 0x0a, 0x07, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x23, 0x0a, 0x07,
+	0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x1a, 0x21, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x1a, 0x55, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x28, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x3a, 0x0a, 0x0e, 0x55,
+	0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x61, 0x73, 0x73, 0x12, 0x28, 0x0a,
+	0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x65,
+	0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x42, 0x0a, 0x05, 0x46, 0x6c, 0x61, 0x6b, 0x79,
+	0x12, 0x39, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x5f, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x0f, 0x64, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x5a, 0x0a, 0x0b, 0x54, 0x65, 0x73, 0x74, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x22, 0xca, 0x01, 0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x65, 0x73, 0x74, 0x55, 0x69, 0x64, 0x12,
+	0x2d, 0x0a, 0x12, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x69, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x55, 0x69, 0x64, 0x12,
+	0x23, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x22, 0x36,
+	0x0a, 0x09, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x61, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x12, 0x36, 0x0a, 0x0b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x7a, 0x0a, 0x13, 0x41, 0x73, 0x73,
+	0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x80, 0x02, 0x0a, 0x06, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x25, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x0a, 0x10, 0x61, 0x73, 0x73, 0x65, 0x72,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x0f, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x36, 0x0a, 0x0e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x73, 0x70,
+	0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x39, 0x0a, 0x0f,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73,
+	0x65, 0x52, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x6f, 0x73, 0x65, 0x70, 0x68, 0x6c, 0x65, 0x77,
+	0x69, 0x73, 0x34, 0x32, 0x2f, 0x73, 0x70, 0x65, 0x63, 0x2d, 0x74, 0x65, 0x73, 0x74, 0x65, 0x72,
+	0x2f, 0x74, 0x65, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1166,50 +2193,84 @@ func file_model_proto_rawDescGZIP() []byte {
 	return file_model_proto_rawDescData
 }
 
-var file_model_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_model_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_model_proto_goTypes = []interface{}{
 	(*Metadata)(nil),                    // 0: Metadata
 	(*TestCase)(nil),                    // 1: TestCase
 	(*SkipTest)(nil),                    // 2: SkipTest
 	(*EvalTest)(nil),                    // 3: EvalTest
-	(*InvalidTest)(nil),                 // 4: InvalidTest
-	(*Implementation)(nil),              // 5: Implementation
-	(*ImplementationVariant)(nil),       // 6: ImplementationVariant
-	(*ImplementationRuntimeLocal)(nil),  // 7: ImplementationRuntimeLocal
-	(*Specification)(nil),               // 8: Specification
-	(*SpecificationSection)(nil),        // 9: SpecificationSection
-	(*SpecificationSectionSummary)(nil), // 10: SpecificationSectionSummary
-	(*SpecificationTestSummary)(nil),    // 11: SpecificationTestSummary
-	(*ProcessOutput)(nil),               // 12: ProcessOutput
-	(*TestResult)(nil),                  // 13: TestResult
-	nil,                                 // 14: Metadata.LabelsEntry
-	(*TestResult_Success)(nil),          // 15: TestResult.Success
-	(*TestResult_Failure)(nil),          // 16: TestResult.Failure
+	(*TestFile)(nil),                    // 4: TestFile
+	(*InvalidTest)(nil),                 // 5: InvalidTest
+	(*Implementation)(nil),              // 6: Implementation
+	(*ImplementationVariant)(nil),       // 7: ImplementationVariant
+	(*Deviation)(nil),                   // 8: Deviation
+	(*ImplementationRuntimeLocal)(nil),  // 9: ImplementationRuntimeLocal
+	(*Specification)(nil),               // 10: Specification
+	(*SpecificationSection)(nil),        // 11: SpecificationSection
+	(*SpecificationSectionSummary)(nil), // 12: SpecificationSectionSummary
+	(*SpecificationTestSummary)(nil),    // 13: SpecificationTestSummary
+	(*ProcessOutput)(nil),               // 14: ProcessOutput
+	(*TestResult)(nil),                  // 15: TestResult
+	(*TestAttempt)(nil),                 // 16: TestAttempt
+	(*TestCaseResult)(nil),              // 17: TestCaseResult
+	(*ResultSet)(nil),                   // 18: ResultSet
+	(*AssertionConfig)(nil),             // 19: AssertionConfig
+	(*AssertionDefinition)(nil),         // 20: AssertionDefinition
+	(*Bundle)(nil),                      // 21: Bundle
+	nil,                                 // 22: Metadata.LabelsEntry
+	(*TestResult_Success)(nil),          // 23: TestResult.Success
+	(*TestResult_Failure)(nil),          // 24: TestResult.Failure
+	(*TestResult_Skipped)(nil),          // 25: TestResult.Skipped
+	(*TestResult_Error)(nil),            // 26: TestResult.Error
+	(*TestResult_ExpectedFailure)(nil),  // 27: TestResult.ExpectedFailure
+	(*TestResult_UnexpectedPass)(nil),   // 28: TestResult.UnexpectedPass
+	(*TestResult_Flaky)(nil),            // 29: TestResult.Flaky
 }
 var file_model_proto_depIdxs = []int32{
-	14, // 0: Metadata.labels:type_name -> Metadata.LabelsEntry
+	22, // 0: Metadata.labels:type_name -> Metadata.LabelsEntry
 	0,  // 1: TestCase.metadata:type_name -> Metadata
 	2,  // 2: TestCase.skip:type_name -> SkipTest
 	3,  // 3: TestCase.eval:type_name -> EvalTest
-	4,  // 4: TestCase.invalid:type_name -> InvalidTest
-	0,  // 5: Implementation.metadata:type_name -> Metadata
-	6,  // 6: Implementation.variants:type_name -> ImplementationVariant
-	0,  // 7: ImplementationVariant.metadata:type_name -> Metadata
-	7,  // 8: ImplementationVariant.local:type_name -> ImplementationRuntimeLocal
-	0,  // 9: Specification.metadata:type_name -> Metadata
-	9,  // 10: Specification.sections:type_name -> SpecificationSection
-	0,  // 11: SpecificationSection.metadata:type_name -> Metadata
-	10, // 12: SpecificationSection.section_summary:type_name -> SpecificationSectionSummary
-	11, // 13: SpecificationSection.test_summary:type_name -> SpecificationTestSummary
-	9,  // 14: SpecificationSectionSummary.subsections:type_name -> SpecificationSection
-	15, // 15: TestResult.success:type_name -> TestResult.Success
-	16, // 16: TestResult.failure:type_name -> TestResult.Failure
-	12, // 17: TestResult.example:type_name -> ProcessOutput
-	18, // [18:18] is the sub-list for method output_type
-	18, // [18:18] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	5,  // 4: TestCase.invalid:type_name -> InvalidTest
+	4,  // 5: EvalTest.files:type_name -> TestFile
+	0,  // 6: Implementation.metadata:type_name -> Metadata
+	7,  // 7: Implementation.variants:type_name -> ImplementationVariant
+	0,  // 8: ImplementationVariant.metadata:type_name -> Metadata
+	9,  // 9: ImplementationVariant.local:type_name -> ImplementationRuntimeLocal
+	8,  // 10: ImplementationVariant.deviations:type_name -> Deviation
+	0,  // 11: Specification.metadata:type_name -> Metadata
+	11, // 12: Specification.sections:type_name -> SpecificationSection
+	0,  // 13: SpecificationSection.metadata:type_name -> Metadata
+	12, // 14: SpecificationSection.section_summary:type_name -> SpecificationSectionSummary
+	13, // 15: SpecificationSection.test_summary:type_name -> SpecificationTestSummary
+	11, // 16: SpecificationSectionSummary.subsections:type_name -> SpecificationSection
+	23, // 17: TestResult.success:type_name -> TestResult.Success
+	24, // 18: TestResult.failure:type_name -> TestResult.Failure
+	14, // 19: TestResult.example:type_name -> ProcessOutput
+	25, // 20: TestResult.skipped:type_name -> TestResult.Skipped
+	26, // 21: TestResult.error:type_name -> TestResult.Error
+	27, // 22: TestResult.expected_failure:type_name -> TestResult.ExpectedFailure
+	28, // 23: TestResult.unexpected_pass:type_name -> TestResult.UnexpectedPass
+	29, // 24: TestResult.flaky:type_name -> TestResult.Flaky
+	15, // 25: TestAttempt.result:type_name -> TestResult
+	14, // 26: TestAttempt.output:type_name -> ProcessOutput
+	15, // 27: TestCaseResult.result:type_name -> TestResult
+	16, // 28: TestCaseResult.attempts:type_name -> TestAttempt
+	17, // 29: ResultSet.results:type_name -> TestCaseResult
+	20, // 30: AssertionConfig.definitions:type_name -> AssertionDefinition
+	0,  // 31: Bundle.metadata:type_name -> Metadata
+	19, // 32: Bundle.assertion_config:type_name -> AssertionConfig
+	10, // 33: Bundle.specifications:type_name -> Specification
+	6,  // 34: Bundle.implementations:type_name -> Implementation
+	1,  // 35: Bundle.tests:type_name -> TestCase
+	8,  // 36: TestResult.ExpectedFailure.deviation:type_name -> Deviation
+	8,  // 37: TestResult.UnexpectedPass.deviation:type_name -> Deviation
+	14, // 38: TestResult.Flaky.distinct_outputs:type_name -> ProcessOutput
+	39, // [39:39] is the sub-list for method output_type
+	39, // [39:39] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_model_proto_init() }
@@ -1267,7 +2328,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InvalidTest); i {
+			switch v := v.(*TestFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1279,7 +2340,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Implementation); i {
+			switch v := v.(*InvalidTest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1291,7 +2352,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImplementationVariant); i {
+			switch v := v.(*Implementation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1303,7 +2364,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImplementationRuntimeLocal); i {
+			switch v := v.(*ImplementationVariant); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1315,7 +2376,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Specification); i {
+			switch v := v.(*Deviation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1327,7 +2388,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecificationSection); i {
+			switch v := v.(*ImplementationRuntimeLocal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1339,7 +2400,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecificationSectionSummary); i {
+			switch v := v.(*Specification); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1351,7 +2412,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecificationTestSummary); i {
+			switch v := v.(*SpecificationSection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1363,7 +2424,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessOutput); i {
+			switch v := v.(*SpecificationSectionSummary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1375,7 +2436,19 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestResult); i {
+			switch v := v.(*SpecificationTestSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessOutput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1387,7 +2460,7 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestResult_Success); i {
+			switch v := v.(*TestResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1399,6 +2472,90 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestAttempt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestCaseResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssertionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssertionDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Bundle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestResult_Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestResult_Failure); i {
 			case 0:
 				return &v.state
@@ -1410,23 +2567,89 @@ func file_model_proto_init() {
 				return nil
 			}
 		}
+		file_model_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestResult_Skipped); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestResult_Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestResult_ExpectedFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestResult_UnexpectedPass); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestResult_Flaky); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_model_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*TestCase_Skip)(nil),
 		(*TestCase_Eval)(nil),
 		(*TestCase_Invalid)(nil),
 	}
-	file_model_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_model_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_model_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*ImplementationVariant_Local)(nil),
 	}
-	file_model_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_model_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*SpecificationSection_SectionSummary)(nil),
 		(*SpecificationSection_TestSummary)(nil),
 	}
-	file_model_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_model_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*TestResult_Success_)(nil),
 		(*TestResult_Failure_)(nil),
 		(*TestResult_Example)(nil),
+		(*TestResult_Skipped_)(nil),
+		(*TestResult_Error_)(nil),
+		(*TestResult_ExpectedFailure_)(nil),
+		(*TestResult_UnexpectedPass_)(nil),
+		(*TestResult_Flaky_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1434,7 +2657,7 @@ func file_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_model_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ReadResultSet reads a stored set of results from the given path.
+func ReadResultSet(path string) (*ResultSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", path, err)
+	}
+
+	out := &ResultSet{}
+	if err := protojson.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("couldn't decode %s: %w", path, err)
+	}
+
+	return out, nil
+}
+
+// WriteResultSet stores a set of results at the given path.
+func WriteResultSet(path string, results *ResultSet) error {
+	data, err := marshalIndentedJSON(results)
+	if err != nil {
+		return fmt.Errorf("couldn't encode results: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("couldn't write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// marshalIndentedJSON encodes the message as indented JSON, protojson's own
+// indentation isn't stable between runs.
+func marshalIndentedJSON(m proto.Message) ([]byte, error) {
+	compact, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+
+	return out.Bytes(), nil
+}
+
+// ForVariant returns the results for a single implementation variant keyed by test UID.
+func (rs *ResultSet) ForVariant(implementationUid, variantUid string) map[string]*TestResult {
+	out := make(map[string]*TestResult)
+	for _, result := range rs.GetResults() {
+		if result.GetImplementationUid() == implementationUid && result.GetVariantUid() == variantUid {
+			out[result.GetTestUid()] = result.GetResult()
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,2 @@
+// Package importer converts existing corpora of test files into tests.
+package importer
@@ -0,0 +1,245 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+var nameRegexp = regexp.MustCompile("^" + v1.NameMatcher + "$")
+
+// labelValue converts text from the corpus path into a label value, with a
+// warning if it had to be changed.
+func labelValue(path, text string, result *Result) string {
+	value := v1.LabelValueFrom(text)
+	if value != text {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %q isn't a valid label value, using %q", path, text, value))
+	}
+	return value
+}
+
+// Format is the layout of a corpus.
+type Format string
+
+const (
+	// FormatPairs is a corpus of input files with expected output files next
+	// to them sharing the same base name, e.g. foo.scm and foo.out.
+	FormatPairs Format = "pairs"
+
+	// FormatPrefix is a corpus where the expected outcome is encoded as a
+	// filename prefix, e.g. y_foo.json, n_foo.json and i_foo.json.
+	FormatPrefix Format = "prefix"
+)
+
+// Options configures how a corpus is imported.
+type Options struct {
+	// Name of the generated test.
+	Name string
+
+	Format Format
+
+	// Extension of the input files, including the leading dot.
+	InputExtension string
+
+	// Extension of expected output files for FormatPairs.
+	ExpectedExtension string
+	// Assertion definition the expected output is passed to as a string for FormatPairs.
+	ExpectationType string
+
+	// Expectations for each filename prefix for FormatPrefix, cases with
+	// unmapped prefixes are skipped.
+	PrefixExpectations map[string]v1.TestExpectation
+	// Label key set to each file's prefix for FormatPrefix, unset if blank.
+	PrefixLabel string
+
+	// Label keys set to each directory name relative to the corpus root,
+	// the first key is set to the top level directory name and so on.
+	DirectoryLabels []string
+}
+
+// Result holds an imported test.
+type Result struct {
+	Test v1.Test
+
+	// Warnings for files that couldn't be imported.
+	Warnings []string
+}
+
+// Import converts the corpus at root into a single test, each directory in the
+// corpus becomes a nested context.
+func Import(root string, opts Options) (*Result, error) {
+	switch opts.Format {
+	case FormatPairs:
+		if opts.ExpectedExtension == "" || opts.ExpectationType == "" {
+			return nil, fmt.Errorf("format %q requires an expected extension and expectation type", opts.Format)
+		}
+	case FormatPrefix:
+		// Expectations may be empty, all cases will be skipped.
+	default:
+		return nil, fmt.Errorf("unknown format %q", opts.Format)
+	}
+
+	if opts.InputExtension == "" {
+		return nil, fmt.Errorf("an input extension is required")
+	}
+
+	if !nameRegexp.MatchString(opts.Name) || len(opts.Name) > 63 {
+		return nil, fmt.Errorf("invalid name %q: must match %s and be at most 63 characters", opts.Name, v1.NameMatcher)
+	}
+
+	for _, key := range append([]string{opts.PrefixLabel}, opts.DirectoryLabels...) {
+		if key == "" {
+			continue
+		}
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+
+	out := &Result{
+		Test: v1.Test{
+			Base: v1.Base{
+				Version: v1.Version{
+					APIVersion: v1.APIVersion,
+					Kind:       v1.KindTest,
+				},
+				Metadata: v1.Metadata{
+					Name: opts.Name,
+				},
+			},
+		},
+	}
+
+	context, err := importDirectory(root, "", 0, opts, out)
+	if err != nil {
+		return nil, err
+	}
+	out.Test.TestContext = *context
+
+	out.Test.Tidy()
+	return out, nil
+}
+
+func importDirectory(root, relPath string, depth int, opts Options, result *Result) (*v1.TestContext, error) {
+	entries, err := os.ReadDir(filepath.Join(root, relPath))
+	if err != nil {
+		return nil, err
+	}
+
+	out := &v1.TestContext{}
+	if depth > 0 && depth <= len(opts.DirectoryLabels) {
+		out.Template.Labels = v1.Labels{
+			opts.DirectoryLabels[depth-1]: labelValue(relPath, filepath.Base(relPath), result),
+		}
+	}
+
+	for _, entry := range entries {
+		entryPath := filepath.Join(relPath, entry.Name())
+
+		if entry.IsDir() {
+			child, err := importDirectory(root, entryPath, depth+1, opts, result)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(child.Tests) > 0 {
+				out.Tests = append(out.Tests, v1.TestContextOrCase{Context: child})
+			}
+			continue
+		}
+
+		if filepath.Ext(entry.Name()) != opts.InputExtension {
+			continue
+		}
+
+		testCase, err := importCase(root, entryPath, opts, result)
+		if err != nil {
+			return nil, err
+		}
+
+		if testCase == nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: blank or not valid UTF-8, skipped", entryPath))
+			continue
+		}
+
+		out.Tests = append(out.Tests, v1.TestContextOrCase{Case: testCase})
+	}
+
+	return out, nil
+}
+
+// importCase converts a single input file into a case, nil is returned if the
+// input can't be represented or is blank.
+func importCase(root, relPath string, opts Options, result *Result) (*v1.TestCase, error) {
+	input, err := os.ReadFile(filepath.Join(root, relPath))
+	if err != nil {
+		return nil, err
+	}
+
+	if !utf8.Valid(input) || strings.TrimSpace(string(input)) == "" {
+		return nil, nil
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(relPath), opts.InputExtension)
+	inputString := string(input)
+
+	out := &v1.TestCase{
+		IdentifiableMetadata: v1.IdentifiableMetadata{
+			DisplayMetadata: v1.DisplayMetadata{
+				DisplayName: baseName,
+				Note:        fmt.Sprintf("Imported from %s", filepath.ToSlash(relPath)),
+			},
+		},
+		Input: &inputString,
+	}
+
+	switch opts.Format {
+	case FormatPairs:
+		expectedPath := strings.TrimSuffix(relPath, opts.InputExtension) + opts.ExpectedExtension
+		expected, err := os.ReadFile(filepath.Join(root, expectedPath))
+		if os.IsNotExist(err) {
+			skip(out, fmt.Sprintf("missing expected output %s", filepath.ToSlash(expectedPath)))
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		options, err := json.Marshal(string(expected))
+		if err != nil {
+			return nil, err
+		}
+		out.Expect = &v1.TestExpectation{opts.ExpectationType: options}
+
+	case FormatPrefix:
+		prefix, _, found := strings.Cut(baseName, "_")
+		if !found {
+			skip(out, "filename has no prefix")
+			return out, nil
+		}
+
+		if opts.PrefixLabel != "" {
+			out.Labels = v1.Labels{opts.PrefixLabel: labelValue(relPath, prefix, result)}
+		}
+
+		expectation, ok := opts.PrefixExpectations[prefix]
+		if !ok {
+			skip(out, fmt.Sprintf("no expectation for prefix %q", prefix))
+			return out, nil
+		}
+		out.Expect = &expectation
+	}
+
+	return out, nil
+}
+
+func skip(testCase *v1.TestCase, reason string) {
+	testCase.Skip = &reason
+}
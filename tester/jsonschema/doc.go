@@ -0,0 +1,2 @@
+// Package jsonschema generates JSON Schema documents from Go types.
+package jsonschema
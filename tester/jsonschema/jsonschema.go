@@ -0,0 +1,250 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema version generated schemas conform to.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document or sub-schema.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type  string `json:"type,omitempty"`
+	Const any    `json:"const,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+
+	Pattern   string `json:"pattern,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+
+	// raw replaces the schema with a literal JSON document when set.
+	raw json.RawMessage
+}
+
+// Raw creates a schema from a literal JSON document.
+func Raw(document json.RawMessage) *Schema {
+	return &Schema{raw: document}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.raw != nil {
+		return s.raw, nil
+	}
+
+	// Alias to avoid recursing into MarshalJSON.
+	type schema Schema
+	return json.Marshal((*schema)(s))
+}
+
+// Int returns a pointer to the value for use in optional schema fields.
+func Int(value int) *int {
+	return &value
+}
+
+// OneOfer is implemented by structs where exactly one of the listed JSON
+// fields may be set.
+type OneOfer interface {
+	OneOfFields() []string
+}
+
+// Generator builds schemas from Go types, named struct types are stored as
+// shared definitions.
+type Generator struct {
+	// Description returns the description of a type, or of one of its fields
+	// if field is set.
+	Description func(t reflect.Type, field string) string
+
+	// Override returns a schema to use instead of the generated one for the
+	// type, or nil to generate it.
+	Override func(t reflect.Type) *Schema
+
+	// Extend refines the generated schema of a struct type.
+	Extend func(t reflect.Type, schema *Schema)
+
+	definitions map[string]*Schema
+}
+
+// Root generates a document for the type, the type itself is inlined and
+// every struct it references is stored as a definition.
+func (g *Generator) Root(t reflect.Type) *Schema {
+	g.definitions = make(map[string]*Schema)
+
+	out := g.structSchema(t)
+	out.Schema = Draft
+	out.Definitions = g.definitions
+
+	return out
+}
+
+func (g *Generator) schemaFor(t reflect.Type) *Schema {
+	if g.Override != nil {
+		if override := g.Override(t); override != nil {
+			return override
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schemaFor(t.Elem())
+
+	case reflect.String:
+		return &Schema{Type: "string"}
+
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+
+	case reflect.Slice, reflect.Array:
+		if t == reflect.TypeOf(json.RawMessage{}) {
+			// Arbitrary JSON.
+			return &Schema{}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			// Reserve the name before recursing so cycles terminate.
+			g.definitions[t.Name()] = nil
+			g.definitions[t.Name()] = g.structSchema(t)
+		}
+		return &Schema{Ref: "#/definitions/" + t.Name()}
+
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	out := &Schema{
+		Type:                 "object",
+		Description:          g.describe(t, ""),
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+
+	g.addFields(t, out)
+
+	if oneOfer, ok := reflect.New(t).Interface().(OneOfer); ok {
+		pointers := make(map[string]bool)
+		eachField(t, func(_ reflect.Type, name string, field reflect.StructField) {
+			pointers[name] = field.Type.Kind() == reflect.Pointer
+		})
+
+		for _, field := range oneOfer.OneOfFields() {
+			// Pointer fields are set whenever they're present.
+			set := &Schema{}
+			if !pointers[field] {
+				set = nonEmpty(out.Properties[field])
+			}
+
+			out.OneOf = append(out.OneOf, &Schema{
+				Required:   []string{field},
+				Properties: map[string]*Schema{field: set},
+			})
+		}
+	}
+
+	if g.Extend != nil {
+		g.Extend(t, out)
+	}
+
+	return out
+}
+
+// addFields adds the JSON fields of the struct to the schema, flattening
+// embedded structs the way encoding/json does.
+func (g *Generator) addFields(t reflect.Type, out *Schema) {
+	eachField(t, func(owner reflect.Type, name string, field reflect.StructField) {
+		schema := g.schemaFor(field.Type)
+		if description := g.describe(owner, field.Name); description != "" {
+			if schema.Ref != "" {
+				// Siblings of $ref are ignored in draft-07.
+				schema = &Schema{AllOf: []*Schema{schema}}
+			}
+			schema.Description = description
+		}
+
+		out.Properties[name] = schema
+	})
+}
+
+// eachField calls callback with the struct declaring each JSON field of t, its
+// name and the field, embedded structs are flattened.
+func eachField(t reflect.Type, callback func(owner reflect.Type, name string, field reflect.StructField)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+
+		case name == "" && field.Anonymous:
+			eachField(field.Type, callback)
+			continue
+
+		case name == "":
+			name = field.Name
+		}
+
+		callback(t, name, field)
+	}
+}
+
+func (g *Generator) describe(t reflect.Type, field string) string {
+	if g.Description == nil {
+		return ""
+	}
+	return g.Description(t, field)
+}
+
+// nonEmpty returns a schema that requires the value to be set to something
+// other than its zero value.
+func nonEmpty(property *Schema) *Schema {
+	if property == nil {
+		return &Schema{}
+	}
+
+	switch property.Type {
+	case "string":
+		return &Schema{MinLength: Int(1)}
+	case "array":
+		return &Schema{MinItems: Int(1)}
+	default:
+		return &Schema{}
+	}
+}
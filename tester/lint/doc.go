@@ -0,0 +1,6 @@
+// Package lint checks suites against optional style rules.
+//
+// Rules are disabled unless enabled in the suite's lint configuration, which
+// also sets the level results are reported at. Objects can suppress rules for
+// themselves and everything nested in them with the lint/disable annotation.
+package lint
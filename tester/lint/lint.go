@@ -0,0 +1,187 @@
+package lint
+
+import (
+	"strings"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+)
+
+const (
+	// DisableAnnotation holds a comma separated list of rules to suppress for
+	// an object and everything nested in it.
+	DisableAnnotation = "lint/disable"
+
+	// LevelOff disables a rule in the lint configuration.
+	LevelOff = "off"
+)
+
+// Kinds of objects rules are run against.
+const (
+	KindTestSuite             = v1.KindTestSuite
+	KindImplementation        = v1.TypeImplementation
+	KindImplementationVariant = "ImplementationVariant"
+	KindSpecification         = v1.TypeSpecification
+	KindSpecificationSection  = "SpecificationSection"
+	KindTest                  = v1.KindTest
+	KindTestContext           = "TestContext"
+	KindTestCase              = "TestCase"
+)
+
+// Object is a part of a suite with metadata that rules are run against.
+type Object struct {
+	// Kind of the object, one of the Kind constants.
+	Kind string
+	// Validator for the field holding the object's metadata.
+	Validator *validation.Validator
+	// Metadata of the object, templates are converted to metadata.
+	Metadata v1.DisplayMetadata
+}
+
+// Linter runs the rules enabled in a suite's configuration.
+type Linter struct {
+	enabled map[string]validation.Level
+}
+
+// NewLinter creates a linter from the configuration, problems with the
+// configuration are reported to the validator and the affected rules skipped.
+// A nil configuration enables no rules.
+func NewLinter(config *v1.TestSuiteSpecLintConfig, validator *validation.Validator) *Linter {
+	known := make(map[string]bool)
+	for _, rule := range rules {
+		known[rule.Name] = true
+	}
+
+	out := &Linter{enabled: make(map[string]validation.Level)}
+	if config == nil {
+		return out
+	}
+
+	for _, name := range sortedKeys(config.Rules) {
+		ruleValidator := validator.Field("rules").AtKey(name)
+		if !known[name] {
+			ruleValidator.Error("unknown lint rule %q", name)
+			continue
+		}
+
+		if config.Rules[name] == LevelOff {
+			continue
+		}
+
+		level, err := validation.ParseLevel(config.Rules[name])
+		if err != nil {
+			ruleValidator.Error("%v, or %s", err, LevelOff)
+			continue
+		}
+		out.enabled[name] = level
+	}
+
+	return out
+}
+
+// LintTestSuite runs the enabled rules against the suite definition.
+func (l *Linter) LintTestSuite(suite *v1.TestSuite, validator *validation.Validator) {
+	l.check(nil, metadataObject(KindTestSuite, validator, suite.Metadata))
+}
+
+// LintImplementation runs the enabled rules against the implementation and its variants.
+func (l *Linter) LintImplementation(impl *v1.Implementation, validator *validation.Validator) {
+	suppressed := l.check(nil, metadataObject(KindImplementation, validator, impl.Metadata))
+
+	for idx, variant := range impl.Variants {
+		l.check(suppressed, metadataObject(KindImplementationVariant, validator.Field("variants").AtIndex(idx), variant.Metadata))
+	}
+}
+
+// LintSpecification runs the enabled rules against the specification and its sections.
+func (l *Linter) LintSpecification(spec *v1.Specification, validator *validation.Validator) {
+	suppressed := l.check(nil, metadataObject(KindSpecification, validator, spec.Metadata))
+	l.lintSections(suppressed, spec.Sections, validator)
+}
+
+func (l *Linter) lintSections(suppressed map[string]bool, sections []v1.SpecificationSection, validator *validation.Validator) {
+	for idx, section := range sections {
+		sectionValidator := validator.Field("sections").AtIndex(idx)
+		sectionSuppressed := l.check(suppressed, metadataObject(KindSpecificationSection, sectionValidator, section.Metadata))
+		l.lintSections(sectionSuppressed, section.Sections, sectionValidator)
+	}
+}
+
+// LintTest runs the enabled rules against the test, its contexts and cases.
+func (l *Linter) LintTest(test *v1.Test, validator *validation.Validator) {
+	suppressed := l.check(nil, metadataObject(KindTest, validator, test.Metadata))
+	l.lintContext(suppressed, &test.TestContext, validator)
+}
+
+func (l *Linter) lintContext(suppressed map[string]bool, context *v1.TestContext, validator *validation.Validator) {
+	suppressed = l.check(suppressed, &Object{
+		Kind:      KindTestContext,
+		Validator: validator.Field("template"),
+		Metadata: v1.DisplayMetadata{
+			Labels:      context.Template.Labels,
+			DisplayName: context.Template.DisplayName,
+			Description: context.Template.Description,
+			Annotations: context.Template.Annotations,
+		},
+	})
+
+	for idx, entry := range context.Tests {
+		entryValidator := validator.Field("tests").AtIndex(idx)
+
+		if entry.Case != nil {
+			l.check(suppressed, &Object{
+				Kind:      KindTestCase,
+				Validator: entryValidator.Field("case"),
+				Metadata:  entry.Case.DisplayMetadata,
+			})
+		}
+
+		if entry.Context != nil {
+			l.lintContext(suppressed, entry.Context, entryValidator.Field("context"))
+		}
+	}
+
+	if context.Matrix != nil {
+		l.check(suppressed, &Object{
+			Kind:      KindTestCase,
+			Validator: validator.Field("matrix").Field("case"),
+			Metadata:  context.Matrix.Case.DisplayMetadata,
+		})
+	}
+}
+
+func metadataObject(kind string, validator *validation.Validator, metadata v1.Metadata) *Object {
+	return &Object{
+		Kind:      kind,
+		Validator: validator.Field("metadata"),
+		Metadata:  metadata.DisplayMetadata,
+	}
+}
+
+// check runs the enabled rules that aren't suppressed against the object and
+// returns the rules suppressed for its children.
+func (l *Linter) check(suppressed map[string]bool, object *Object) map[string]bool {
+	if disabled, ok := object.Metadata.Annotations[DisableAnnotation]; ok {
+		merged := make(map[string]bool)
+		for name := range suppressed {
+			merged[name] = true
+		}
+		for _, name := range strings.Split(disabled, ",") {
+			merged[strings.TrimSpace(name)] = true
+		}
+		suppressed = merged
+	}
+
+	for _, rule := range rules {
+		level, ok := l.enabled[rule.Name]
+		if !ok || suppressed[rule.Name] {
+			continue
+		}
+
+		rule.Check(object, func(validator *validation.Validator, format string, a ...any) {
+			validator.Report(rule.Name, level, format, a...)
+		})
+	}
+
+	return suppressed
+}
@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"sort"
+	"strings"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+)
+
+// Rule is a named style check.
+type Rule struct {
+	Name        string
+	Description string
+
+	// Check reports problems with the object.
+	Check func(object *Object, report Reporter)
+}
+
+// Reporter records a problem at the field of the validator.
+type Reporter func(validator *validation.Validator, format string, a ...any)
+
+var rules = []Rule{
+	{
+		Name:        "case-display-name",
+		Description: "Test cases must set a displayName.",
+		Check: func(object *Object, report Reporter) {
+			if object.Kind == KindTestCase && strings.TrimSpace(object.Metadata.DisplayName) == "" {
+				report(object.Validator.Field("displayName"), "test cases must have a display name")
+			}
+		},
+	},
+	{
+		Name:        "section-description",
+		Description: "Specification sections must set a description.",
+		Check: func(object *Object, report Reporter) {
+			if object.Kind == KindSpecificationSection && strings.TrimSpace(object.Metadata.Description) == "" {
+				report(object.Validator.Field("description"), "sections must have a description")
+			}
+		},
+	},
+	{
+		Name:        "namespaced-label-keys",
+		Description: "Label keys must have a prefix, e.g. r4rs/heading.",
+		Check: func(object *Object, report Reporter) {
+			for _, key := range sortedKeys(object.Metadata.Labels) {
+				if !strings.Contains(key, "/") {
+					report(object.Validator.Field("labels").AtKey(key), "label key %q must be namespaced like prefix/%s", key, key)
+				}
+			}
+		},
+	},
+}
+
+// Rules returns every known rule sorted by name.
+func Rules() []Rule {
+	out := append([]Rule(nil), rules...)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func sortedKeys(labels v1.Labels) (out []string) {
+	for key := range labels {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return
+}
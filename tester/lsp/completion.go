@@ -0,0 +1,229 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+)
+
+// complete suggests label keys and values used elsewhere in the suite,
+// expectation types from the suite's assertion definitions and specification
+// names.
+//
+// Documents are usually invalid while being edited so the context is found
+// from indentation rather than by parsing.
+func (s *Server) complete(params textDocumentPositionParams) []CompletionItem {
+	out := []CompletionItem{}
+
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return out
+	}
+
+	line := doc.line(params.Position.Line)
+	prefix := line[:utf8Offset(line, params.Position.Character)]
+	chain := keyChain(doc, params.Position.Line, prefix)
+
+	parent := ""
+	if len(chain) > 0 {
+		parent = chain[len(chain)-1]
+	}
+
+	typed := strings.TrimLeft(strings.TrimSpace(prefix), "- ")
+	key, value, isValue := strings.Cut(typed, ":")
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	switch {
+	case parent == "labels" && !isValue:
+		for _, labelKey := range sortedKeys(s.labels(doc)) {
+			out = append(out, CompletionItem{
+				Label:      labelKey,
+				Kind:       CompletionKindProperty,
+				Detail:     "label",
+				InsertText: labelKey + ": ",
+			})
+		}
+
+	case parent == "labels" && isValue:
+		for _, labelValue := range sortedKeys(s.labels(doc)[key]) {
+			out = append(out, CompletionItem{
+				Label:  labelValue,
+				Kind:   CompletionKindValue,
+				Detail: key,
+			})
+		}
+
+	case parent == "expect" && !isValue:
+		if s.suite == nil {
+			break
+		}
+		for _, defn := range s.suite.TestSuite.Value.Spec.Assertions.Definitions {
+			out = append(out, CompletionItem{
+				Label:      defn.Name,
+				Kind:       CompletionKindProperty,
+				Detail:     "assertion",
+				InsertText: defn.Name + ": ",
+			})
+		}
+
+	case parent == "specifications" && !isValue:
+		if s.suite == nil {
+			break
+		}
+		for _, spec := range s.suite.Specifications {
+			out = append(out, CompletionItem{
+				Label:  spec.Value.Metadata.Name,
+				Kind:   CompletionKindValue,
+				Detail: spec.Value.Metadata.DisplayName,
+			})
+		}
+		for _, imp := range s.suite.Imports {
+			for _, spec := range imp.Specifications {
+				out = append(out, CompletionItem{
+					Label:  spec.GetMetadata().GetUid(),
+					Kind:   CompletionKindValue,
+					Detail: fmt.Sprintf("%s (imported from %s)", spec.GetMetadata().GetDisplayName(), imp.Name),
+				})
+			}
+		}
+
+	case isValue && (key == "testSelector" || key == "exclusionTestSelector"):
+		if strings.ContainsAny(value, "=!, ") {
+			break
+		}
+		labels := s.labels(doc)
+		for _, labelKey := range sortedKeys(labels) {
+			for _, labelValue := range sortedKeys(labels[labelKey]) {
+				out = append(out, CompletionItem{
+					Label:  labelKey + "=" + labelValue,
+					Kind:   CompletionKindValue,
+					Detail: "label selector",
+				})
+			}
+		}
+	}
+
+	return out
+}
+
+// keyChain returns the mapping keys that are parents of the line based on
+// indentation, the prefix is the text of the line before the cursor.
+func keyChain(doc *document, line int, prefix string) (out []string) {
+	indent := keyIndent(prefix)
+
+	for previous := line - 1; previous >= 0 && indent > 0; previous-- {
+		text := doc.line(previous)
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		lineIndent := keyIndent(text)
+		if lineIndent >= indent {
+			continue
+		}
+		indent = lineIndent
+
+		key, _, ok := strings.Cut(strings.TrimLeft(trimmed, "- "), ":")
+		if ok {
+			out = append([]string{strings.TrimSpace(key)}, out...)
+		}
+	}
+
+	return
+}
+
+// keyIndent returns the column keys on the line start at, including the
+// indentation added by sequence item markers.
+func keyIndent(line string) int {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := len(line) - len(trimmed)
+
+	for strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+		withoutDash := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+		indent += len(trimmed) - len(withoutDash)
+		trimmed = withoutDash
+	}
+
+	return indent
+}
+
+// labels returns the label values in use keyed by label key.
+func (s *Server) labels(doc *document) map[string]map[string]bool {
+	out := make(map[string]map[string]bool)
+	add := func(labels v1.Labels) {
+		for key, value := range labels {
+			if value == "" {
+				// Values still being typed.
+				continue
+			}
+			if out[key] == nil {
+				out[key] = make(map[string]bool)
+			}
+			out[key][value] = true
+		}
+	}
+
+	var tests []*v1.Test
+	for _, sec := range doc.sections {
+		if value, err := decode(sec); err == nil {
+			if test, ok := value.(*v1.Test); ok {
+				tests = append(tests, test)
+			}
+		}
+	}
+
+	if s.suite != nil {
+		for _, label := range s.suite.TestSuite.Value.Spec.Labels {
+			if out[label.Key] == nil {
+				out[label.Key] = make(map[string]bool)
+			}
+			for _, value := range label.Values {
+				out[label.Key][value.Value] = true
+			}
+		}
+		for _, spec := range s.suite.Specifications {
+			add(spec.Value.Metadata.Labels)
+		}
+		for _, impl := range s.suite.Implementations {
+			add(impl.Value.Metadata.Labels)
+		}
+		for i := range s.suite.Tests {
+			tests = append(tests, &s.suite.Tests[i].Value)
+		}
+	}
+
+	for _, test := range tests {
+		test.WalkEffectiveCases(func(tc *v1.TestCase, parent v1.HydratedTestCaseTemplate) {
+			add(tc.Labels.MergeOver(parent.Labels))
+		})
+	}
+
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) (out []string) {
+	for key := range m {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return
+}
+
+// utf8Offset converts a UTF-16 offset into the line to a byte offset.
+func utf8Offset(line string, character int) int {
+	units := 0
+	for offset, r := range line {
+		if units >= character {
+			return offset
+		}
+		units++
+		if r >= 0x10000 {
+			units++
+		}
+	}
+	return len(line)
+}
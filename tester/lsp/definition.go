@@ -0,0 +1,53 @@
+package lsp
+
+import (
+	"os"
+
+	"github.com/josephlewis42/scheme-compliance/internal/yamlpath"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+)
+
+// definition resolves entries of a variant's specifications to the
+// specification's name.
+func (s *Server) definition(params textDocumentPositionParams) []Location {
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok || s.suite == nil {
+		return nil
+	}
+
+	sec := doc.sectionAt(params.Position.Line)
+	if sec.kind() != v1.TypeImplementation {
+		return nil
+	}
+
+	path := pathAt(sec.root, params.Position.Line)
+	if len(path) < 2 || path[len(path)-2].Key != "specifications" || path[len(path)-1].Value.Line-1 != params.Position.Line {
+		return nil
+	}
+	name := path[len(path)-1].Value.Value
+
+	for _, spec := range s.suite.Specifications {
+		if spec.Value.Metadata.Name != name {
+			continue
+		}
+
+		text, err := os.ReadFile(spec.Path)
+		if err != nil {
+			return nil
+		}
+
+		// The file may hold several documents.
+		specDoc := newDocument(pathToURI(spec.Path), string(text))
+		for _, sec := range specDoc.sections {
+			_, metadata := yamlpath.Lookup(sec.root, "metadata")
+			if _, nameNode := yamlpath.Lookup(metadata, "name"); nameNode != nil && nameNode.Value == name {
+				return []Location{{
+					URI:   specDoc.uri,
+					Range: specDoc.nodeRange(nameNode),
+				}}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/internal/yamlpath"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const diagnosticSource = "spec-tester"
+
+// kinds creates an empty value for each kind of document.
+var kinds = map[string]func() validation.Validatable{
+	v1.KindTest:           func() validation.Validatable { return &v1.Test{} },
+	v1.KindTestSuite:      func() validation.Validatable { return &v1.TestSuite{} },
+	v1.TypeSpecification:  func() validation.Validatable { return &v1.Specification{} },
+	v1.TypeImplementation: func() validation.Validatable { return &v1.Implementation{} },
+}
+
+var (
+	errorLine    = regexp.MustCompile(`line (\d+)`)
+	unknownField = regexp.MustCompile(`unknown field "([^"]+)"`)
+)
+
+// decode decodes the section into the type for its kind.
+func decode(sec *section) (validation.Validatable, error) {
+	factory, ok := kinds[sec.kind()]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %q", sec.kind())
+	}
+
+	out := factory()
+	if err := yaml.UnmarshalStrict([]byte(sec.text), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// diagnose reports parsing errors in each section of the document and the
+// suite's validation results for it. Documents that aren't part of the
+// loaded suite have each section validated on its own, sections without a
+// kind aren't part of a suite and have no diagnostics.
+func (s *Server) diagnose(doc *document) []Diagnostic {
+	results, inSuite := s.results[absPath(uriToPath(doc.uri))]
+
+	out := []Diagnostic{}
+	for _, sec := range doc.sections {
+		out = append(out, diagnoseSection(doc, sec, !inSuite)...)
+	}
+	for _, result := range results {
+		out = append(out, resultDiagnostic(doc, result))
+	}
+	return out
+}
+
+// diagnoseSection reports errors parsing the section, and its validation
+// results if validate is set.
+func diagnoseSection(doc *document, sec *section, validate bool) (out []Diagnostic) {
+	if sec.parseErr != nil {
+		return append(out, errorDiagnostic(doc, sec, sec.parseErr))
+	}
+
+	if sec.kind() == "" {
+		return out
+	}
+
+	if _, ok := kinds[sec.kind()]; !ok {
+		_, kindNode := yamlpath.Lookup(sec.root, "kind")
+		kindNames := make([]string, 0, len(kinds))
+		for kind := range kinds {
+			kindNames = append(kindNames, kind)
+		}
+		sort.Strings(kindNames)
+
+		return append(out, Diagnostic{
+			Range:    doc.nodeRange(kindNode),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  fmt.Sprintf("unknown kind %q, must be one of %q", sec.kind(), kindNames),
+		})
+	}
+
+	value, err := decode(sec)
+	if err != nil {
+		return append(out, errorDiagnostic(doc, sec, err))
+	}
+
+	if !validate {
+		return out
+	}
+
+	validator := &validation.Validator{}
+	value.Validate(validator)
+
+	for _, result := range validator.Results {
+		out = append(out, Diagnostic{
+			Range:    doc.nodeRange(yamlpath.Find(sec.root, result.Field)),
+			Severity: severity(result.Level),
+			Source:   diagnosticSource,
+			Message:  resultMessage(result),
+		})
+	}
+
+	return out
+}
+
+// resultDiagnostic places a result of validating the suite at its position,
+// or the start of the document if it has none.
+func resultDiagnostic(doc *document, result validation.Result) Diagnostic {
+	out := Diagnostic{
+		Range:    doc.lineRange(0),
+		Severity: severity(result.Level),
+		Source:   diagnosticSource,
+		Message:  resultMessage(result),
+	}
+
+	if result.Line > 0 {
+		line := result.Line - 1
+		out.Range = doc.lineRange(line)
+		out.Range.Start.Character = utf16Len(doc.line(line), result.Column-1)
+
+		if node := yamlpath.Find(doc.sectionAt(line).node(), result.Field); node != nil && node.Line == result.Line {
+			out.Range = doc.nodeRange(node)
+		}
+	}
+
+	return out
+}
+
+func resultMessage(result validation.Result) string {
+	message := fmt.Sprintf("%s: %s", strings.TrimPrefix(result.Field, "."), result.Message)
+	if result.Rule != "" {
+		message = fmt.Sprintf("%s (%s)", message, result.Rule)
+	}
+	return message
+}
+
+// errorDiagnostic places a parsing error at the line or field it mentions,
+// or the start of the section.
+func errorDiagnostic(doc *document, sec *section, err error) Diagnostic {
+	out := Diagnostic{
+		Range:    doc.lineRange(sec.start),
+		Severity: SeverityError,
+		Source:   diagnosticSource,
+		Message:  err.Error(),
+	}
+
+	if match := unknownField.FindStringSubmatch(err.Error()); match != nil {
+		if key := yamlpath.FindKey(sec.root, match[1]); key != nil {
+			out.Range = doc.nodeRange(key)
+		}
+	} else if match := errorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		out.Range = doc.lineRange(line - 1)
+	}
+
+	return out
+}
+
+func severity(level validation.Level) DiagnosticSeverity {
+	switch level {
+	case validation.LevelError:
+		return SeverityError
+	case validation.LevelWarning:
+		return SeverityWarning
+	default:
+		return SeverityInformation
+	}
+}
@@ -0,0 +1,5 @@
+// Package lsp implements a Language Server Protocol server for suite YAML files.
+//
+// Only the subset of the protocol needed for diagnostics, completion, hover and
+// go-to-definition is supported, documents are always synced in full.
+package lsp
@@ -0,0 +1,120 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// hover shows the hydrated test case under the cursor, templates of every
+// parent context are merged in.
+func (s *Server) hover(params textDocumentPositionParams) *Hover {
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+
+	sec := doc.sectionAt(params.Position.Line)
+	if sec.kind() != v1.KindTest {
+		return nil
+	}
+
+	value, err := decode(sec)
+	if err != nil {
+		return nil
+	}
+	test := value.(*v1.Test)
+
+	casePath, caseKey := casePathAt(sec, test, params.Position.Line)
+	if caseKey == nil {
+		return nil
+	}
+
+	var out *Hover
+	test.WalkEffectiveCases(func(tc *v1.TestCase, parent v1.HydratedTestCaseTemplate) {
+		if parent.Path != casePath {
+			return
+		}
+
+		caseRange := doc.nodeRange(caseKey)
+		out = &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: describeCase(tc, parent)},
+			Range:    &caseRange,
+		}
+	})
+
+	return out
+}
+
+// casePathAt returns the template path of the case at the line and its key.
+func casePathAt(sec *section, test *v1.Test, line int) (string, *yaml.Node) {
+	path := pathAt(sec.root, line)
+	casePath := "/" + test.Metadata.Name
+
+	for idx, element := range path {
+		switch {
+		case element.Key == "tests" && idx+1 < len(path):
+			casePath += fmt.Sprintf("/tests/%d", path[idx+1].Index)
+
+		case element.Key == "case":
+			return casePath, element.Node
+		}
+	}
+
+	return "", nil
+}
+
+func describeCase(tc *v1.TestCase, parent v1.HydratedTestCaseTemplate) string {
+	if tc.Input == nil {
+		// Hydrating requires an input, it doesn't affect what's shown.
+		copy := *tc
+		copy.Input = new(string)
+		tc = &copy
+	}
+	hydrated := tc.Hydrate(parent)
+	metadata := hydrated.GetMetadata()
+
+	out := &strings.Builder{}
+	if metadata.GetDisplayName() != "" {
+		fmt.Fprintf(out, "**%s**\n\n", metadata.GetDisplayName())
+	}
+	fmt.Fprintf(out, "UID: `%s`\n\n", metadata.GetUid())
+
+	if description := metadata.GetDescriptionMarkdown(); description != "" {
+		fmt.Fprintf(out, "%s\n\n", description)
+	}
+
+	switch testType := hydrated.TestType.(type) {
+	case *executor.TestCase_Eval:
+		fmt.Fprintf(out, "Expect: `%s`: `%s`\n\n", testType.Eval.GetExpectationType(), testType.Eval.GetExpectationOptionsJson())
+		if testType.Eval.Retries != nil {
+			fmt.Fprintf(out, "Retries: %d\n\n", testType.Eval.GetRetries())
+		}
+		if testType.Eval.GetRawInput() != testType.Eval.GetInput() {
+			fmt.Fprintf(out, "Effective input:\n\n```scheme\n%s\n```\n\n", testType.Eval.GetInput())
+		}
+	case *executor.TestCase_Skip:
+		fmt.Fprintf(out, "Skipped: %s\n\n", testType.Skip.GetMessage())
+	case *executor.TestCase_Invalid:
+		fmt.Fprintf(out, "Invalid: %s\n\n", testType.Invalid.GetMessage())
+	}
+
+	var labelKeys []string
+	for key := range metadata.GetLabels() {
+		labelKeys = append(labelKeys, key)
+	}
+	sort.Strings(labelKeys)
+
+	if len(labelKeys) > 0 {
+		fmt.Fprintln(out, "Labels:")
+		for _, key := range labelKeys {
+			fmt.Fprintf(out, "- `%s`: `%s`\n", key, metadata.GetLabels()[key])
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,302 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+
+	"github.com/josephlewis42/scheme-compliance/internal/specctx"
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+)
+
+// Server is a language server for a single suite.
+type Server struct {
+	root string
+	conn *conn
+
+	docs  map[string]*document
+	suite *storage.Suite
+	// results holds the suite's validation results keyed by the absolute path
+	// of each file in it, nil if the suite couldn't be loaded with the open
+	// documents.
+	results map[string][]validation.Result
+}
+
+// NewServer creates a server for the suite at root, if root is empty the
+// workspace root supplied by the client is used.
+func NewServer(root string) *Server {
+	return &Server{
+		root: root,
+		docs: make(map[string]*document),
+	}
+}
+
+// Serve handles messages until the client sends exit or the input closes.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	log := specctx.GetLogger(ctx)
+	s.conn = newConn(r, w)
+
+	for {
+		msg, err := s.conn.read()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(ctx, msg)
+
+		switch {
+		case msg.ID == nil:
+			// Notifications don't get responses.
+			if err != nil {
+				log.Warn("Couldn't handle notification", "method", msg.Method, "error", err)
+			}
+			continue
+
+		case err != nil:
+			var rpcErr *methodError
+			code := codeInternalError
+			if errors.As(err, &rpcErr) {
+				code = rpcErr.code
+			}
+			err = s.conn.replyError(msg.ID, code, err)
+
+		default:
+			err = s.conn.reply(msg.ID, result)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type methodError struct {
+	code int
+	err  error
+}
+
+func (e *methodError) Error() string {
+	return e.err.Error()
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params initializeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(ctx, params), nil
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.update(ctx, params.TextDocument.URI, params.TextDocument.Text)
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// Documents are synced in full so the last change holds the whole text.
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, s.update(ctx, params.TextDocument.URI, text)
+
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		s.reloadSuite(ctx)
+		return nil, s.publishAllDiagnostics()
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		if err := s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		}); err != nil {
+			return nil, err
+		}
+		// The file on disk replaces the closed document.
+		s.reloadSuite(ctx)
+		return nil, s.publishAllDiagnostics()
+
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.complete(params), nil
+
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params), nil
+
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.definition(params), nil
+
+	default:
+		return nil, &methodError{codeMethodNotFound, fmt.Errorf("method %q not supported", msg.Method)}
+	}
+}
+
+func decodeParams(msg *message, params any) error {
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		return &methodError{codeInvalidParams, fmt.Errorf("invalid params for %q: %w", msg.Method, err)}
+	}
+	return nil
+}
+
+func (s *Server) initialize(ctx context.Context, params initializeParams) any {
+	if s.root == "" {
+		s.root = uriToPath(params.RootURI)
+	}
+	s.reloadSuite(ctx)
+
+	return map[string]any{
+		"capabilities": map[string]any{
+			// Full document sync.
+			"textDocumentSync": map[string]any{
+				"openClose": true,
+				"change":    1,
+				"save":      true,
+			},
+			"completionProvider": map[string]any{
+				"triggerCharacters": []string{":", " ", "-"},
+			},
+			"hoverProvider":      true,
+			"definitionProvider": true,
+		},
+		"serverInfo": map[string]any{
+			"name": "spec-tester",
+		},
+	}
+}
+
+// reloadSuite loads the suite from disk with the open documents in place of
+// their files and validates it, the previous suite is kept if it can't be
+// loaded.
+func (s *Server) reloadSuite(ctx context.Context) {
+	s.results = nil
+	if s.root == "" {
+		return
+	}
+
+	overlay := make(map[string][]byte)
+	for uri, doc := range s.docs {
+		if path := absPath(uriToPath(uri)); path != "" {
+			overlay[path] = []byte(doc.text)
+		}
+	}
+
+	suite, err := storage.LoadSuiteOverlay(s.root, overlay)
+	if err != nil {
+		specctx.GetLogger(ctx).Warn("Couldn't load suite", "path", s.root, "error", err)
+		return
+	}
+	s.suite = suite
+
+	s.results = make(map[string][]validation.Result)
+	for path := range suite.SourceKinds() {
+		s.results[absPath(path)] = nil
+	}
+	suite.RunValidation(func(_ string, v *validation.Validator) {
+		for _, result := range v.Results {
+			path := absPath(result.File)
+			s.results[path] = append(s.results[path], result)
+		}
+	})
+}
+
+// update replaces the text of the document, edits may change the
+// diagnostics of every open document.
+func (s *Server) update(ctx context.Context, uri, text string) error {
+	s.docs[uri] = newDocument(uri, text)
+	s.reloadSuite(ctx)
+	return s.publishAllDiagnostics()
+}
+
+func (s *Server) publishAllDiagnostics() error {
+	uris := make([]string, 0, len(s.docs))
+	for uri := range s.docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	for _, uri := range uris {
+		if err := s.publishDiagnostics(s.docs[uri]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) publishDiagnostics(doc *document) error {
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         doc.uri,
+		Diagnostics: s.diagnose(doc),
+	})
+}
+
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(parsed.Path)
+}
+
+// absPath returns the absolute path, or an empty string if there's none.
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	return abs
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
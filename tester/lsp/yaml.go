@@ -0,0 +1,188 @@
+package lsp
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"github.com/josephlewis42/scheme-compliance/internal/yamlpath"
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// document is an open text document.
+type document struct {
+	uri   string
+	text  string
+	lines []string
+
+	// sections holds each YAML document in the text, separated by "---".
+	sections []*section
+}
+
+// section is a single YAML document in an open text document.
+type section struct {
+	// start and end are the first and last lines of the section.
+	start, end int
+	// text of the section, padded with empty lines so node and error lines
+	// match the document's.
+	text string
+
+	// root is the parsed content of the section, nil if it couldn't be parsed.
+	root     *yaml.Node
+	parseErr error
+}
+
+func newDocument(uri, text string) *document {
+	doc := &document{
+		uri:   uri,
+		text:  text,
+		lines: strings.Split(text, "\n"),
+	}
+
+	parts := storage.SplitDocuments([]byte(text))
+	for idx, part := range parts {
+		sec := &section{
+			start: part.Line,
+			end:   len(doc.lines) - 1,
+			text:  strings.Repeat("\n", part.Line) + string(part.Data),
+		}
+		if idx+1 < len(parts) {
+			sec.end = parts[idx+1].Line - 1
+		}
+
+		var node yaml.Node
+		switch err := yaml.Unmarshal([]byte(sec.text), &node); {
+		case err != nil:
+			sec.parseErr = err
+		case len(node.Content) > 0:
+			sec.root = node.Content[0]
+		}
+
+		doc.sections = append(doc.sections, sec)
+	}
+
+	return doc
+}
+
+// sectionAt returns the section containing the line, nil if there's none.
+func (doc *document) sectionAt(line int) *section {
+	for _, sec := range doc.sections {
+		if sec.start <= line && line <= sec.end {
+			return sec
+		}
+	}
+	return nil
+}
+
+// node returns the parsed content of the section, nil if there's none.
+func (sec *section) node() *yaml.Node {
+	if sec == nil {
+		return nil
+	}
+	return sec.root
+}
+
+// kind returns the value of the top level kind field.
+func (sec *section) kind() string {
+	if sec == nil {
+		return ""
+	}
+	if _, value := yamlpath.Lookup(sec.root, "kind"); value != nil {
+		return value.Value
+	}
+	return ""
+}
+
+// nodeRange returns the range from the start of the node to the end of its
+// first line, or the end of its value for single line scalars.
+func (doc *document) nodeRange(node *yaml.Node) Range {
+	if node == nil {
+		return doc.lineRange(0)
+	}
+
+	line := node.Line - 1
+	out := doc.lineRange(line)
+	out.Start.Character = utf16Len(doc.line(line), node.Column-1)
+
+	if node.Kind == yaml.ScalarNode && node.Style == 0 && !strings.Contains(node.Value, "\n") {
+		out.End.Character = out.Start.Character + len(utf16.Encode([]rune(node.Value)))
+	}
+
+	return out
+}
+
+func (doc *document) lineRange(line int) Range {
+	return Range{
+		Start: Position{Line: line},
+		End:   Position{Line: line, Character: utf16Len(doc.line(line), -1)},
+	}
+}
+
+func (doc *document) line(line int) string {
+	if line < 0 || line >= len(doc.lines) {
+		return ""
+	}
+	return strings.TrimSuffix(doc.lines[line], "\r")
+}
+
+// utf16Len returns the number of UTF-16 code units in the first n bytes of s,
+// or all of s if n is negative.
+func utf16Len(s string, n int) int {
+	if n >= 0 && n < len(s) {
+		s = s[:n]
+	}
+	return len(utf16.Encode([]rune(s)))
+}
+
+// pathElement is a mapping key or sequence index.
+type pathElement struct {
+	Key   string
+	Index int
+	// Node holds the key for mappings and the item for sequences.
+	Node *yaml.Node
+	// Value holds the value at the element.
+	Value *yaml.Node
+}
+
+// pathAt returns the path to the deepest node starting at or before the line.
+func pathAt(root *yaml.Node, line int) (out []pathElement) {
+	node := root
+	for node != nil && node.Line-1 <= line {
+		var next *pathElement
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Line-1 <= line {
+					next = &pathElement{Key: node.Content[i].Value, Node: node.Content[i], Value: node.Content[i+1]}
+				}
+			}
+
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				if item.Line-1 <= line {
+					next = &pathElement{Index: i, Node: item, Value: item}
+				}
+			}
+		}
+
+		if next == nil {
+			return
+		}
+
+		out = append(out, *next)
+		node = next.Value
+	}
+
+	return
+}
+
+// keys returns the mapping keys of the path in order, skipping indexes.
+func keys(path []pathElement) (out []string) {
+	for _, element := range path {
+		if element.Node != element.Value {
+			out = append(out, element.Key)
+		}
+	}
+	return
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+)
+
+// testFilePath returns the path of a test file's content relative to the
+// file the test is defined in.
+func (s *Suite) testFilePath(testPath, path string) string {
+	files := s.fileSystem()
+	return files.join(files.dir(testPath), path)
+}
+
+// resolveTestFile returns the path of a test file's content, or an error if
+// it's absolute or outside the suite's directory.
+func (s *Suite) resolveTestFile(testPath, path string) (string, error) {
+	files := s.fileSystem()
+	if files.isAbs(path) {
+		return "", fmt.Errorf("%q must be relative to the file the test is defined in", path)
+	}
+
+	resolved := s.testFilePath(testPath, path)
+	rel, err := files.rel(s.RootPath, resolved)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("%q is outside the suite's directory", path)
+	}
+
+	return resolved, nil
+}
+
+// TestFilePaths returns the paths of the files tests read content from, paths
+// outside the suite's directory are left out.
+func (s *Suite) TestFilePaths() (out []string) {
+	for _, test := range s.Tests {
+		test.Value.WalkFiles(func(file *v1.TestCaseFile) {
+			if file.Path == "" {
+				return
+			}
+			if path, err := s.resolveTestFile(test.Path, file.Path); err == nil {
+				out = append(out, path)
+			}
+		})
+	}
+	return
+}
+
+// moveTestFiles rewrites the relative paths the test reads files from so they
+// stay the same after the test moves between files.
+func (s *Suite) moveTestFiles(test *v1.Test, from, to string) error {
+	files := s.fileSystem()
+	if files.dir(from) == files.dir(to) {
+		return nil
+	}
+
+	moved := make(map[*v1.TestCaseFile]string)
+	var err error
+	test.WalkFiles(func(file *v1.TestCaseFile) {
+		if file.Path == "" || files.isAbs(file.Path) || err != nil {
+			return
+		}
+		moved[file], err = files.rel(files.dir(to), s.testFilePath(from, file.Path))
+	})
+	if err != nil {
+		return err
+	}
+
+	for file, path := range moved {
+		file.Path = path
+	}
+	return nil
+}
+
+// readTestFiles fills in the content of test files read from paths, the test
+// becomes invalid if one can't be read.
+func (s *Suite) readTestFiles(testPath string, test *executor.TestCase) *executor.TestCase {
+	for _, file := range test.GetEval().GetFiles() {
+		if file.GetPath() == "" {
+			continue
+		}
+
+		path, err := s.resolveTestFile(testPath, file.GetPath())
+		var data []byte
+		if err == nil {
+			data, err = s.fileSystem().readFile(path)
+		}
+		if err != nil {
+			test.TestType = &executor.TestCase_Invalid{
+				Invalid: &executor.InvalidTest{
+					Message: fmt.Sprintf("couldn't read test file %q: %v", file.GetName(), err),
+				},
+			}
+			return test
+		}
+
+		file.Content = string(data)
+		file.Path = ""
+	}
+
+	return test
+}
+
+// validateTestFiles checks the files tests read content from exist within the
+// suite's directory.
+func (s *Suite) validateTestFiles(validators *suiteValidators) {
+	for idx, test := range s.Tests {
+		test.Value.WalkCaseFields(validators.tests[idx], func(validator *validation.Validator, tc *v1.TestCase, _ v1.HydratedTestCaseTemplate) {
+			for fileIdx, file := range tc.Files {
+				if file.Path == "" {
+					continue
+				}
+
+				pathValidator := validator.Field("files").AtIndex(fileIdx).Field("path")
+				path, err := s.resolveTestFile(test.Path, file.Path)
+				if err != nil {
+					pathValidator.Error("%v", err)
+					continue
+				}
+
+				info, err := s.fileSystem().stat(path)
+				switch {
+				case err != nil:
+					pathValidator.Error("couldn't read %q: %v", file.Path, err)
+				case info.IsDir():
+					pathValidator.Error("%q is a directory", file.Path)
+				}
+			}
+		})
+	}
+}
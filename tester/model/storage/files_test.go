@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+)
+
+func TestLoadSuiteFS_testFileOutsideRoot(t *testing.T) {
+	fsys := fstest.MapFS{
+		"s/suite.yaml": mapFile(testSuiteYAML("suite", "")),
+		"s/tests/files.yaml": mapFile(`apiVersion: compliancetest/v1
+kind: Test
+metadata:
+  name: files
+tests:
+- case:
+    uuid: 0d8f7a52-3c1e-4b7a-9f5d-2e6c8b1a4f90
+    input: (load "secret.txt")
+    expect:
+      exact: secret
+    files:
+    - name: secret.txt
+      path: ../../secret.txt
+`),
+		"secret.txt": mapFile("secret"),
+	}
+
+	suite, err := LoadSuiteFS(fsys, "s")
+	if err != nil {
+		t.Fatalf("LoadSuiteFS() = %v", err)
+	}
+
+	var errs []string
+	suite.RunValidation(func(name string, v *validation.Validator) {
+		for _, result := range v.Results {
+			if result.Level == validation.LevelError {
+				errs = append(errs, result.String())
+			}
+		}
+	})
+	if len(errs) != 1 || !strings.Contains(errs[0], "outside the suite's directory") {
+		t.Errorf("RunValidation() errors = %q, want the path to be outside the suite's directory", errs)
+	}
+
+	tests := suite.ListTests()
+	if len(tests) != 1 {
+		t.Fatalf("ListTests() returned %d tests, want 1", len(tests))
+	}
+	if tests[0].GetInvalid() == nil {
+		t.Errorf("ListTests() = %v, want an invalid test", tests[0])
+	}
+
+	if paths := suite.TestFilePaths(); len(paths) != 0 {
+		t.Errorf("TestFilePaths() = %q, want none", paths)
+	}
+}
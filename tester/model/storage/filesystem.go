@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// WriteFS is a filesystem suites loaded with LoadSuiteFS can be saved to.
+type WriteFS interface {
+	fs.FS
+
+	// WriteFile writes data to the named file, creating it and any missing
+	// parent directories.
+	WriteFile(name string, data []byte) error
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+}
+
+// fileSystem is the filesystem a suite is loaded from and saved to.
+type fileSystem interface {
+	walk(root string, fn fs.WalkDirFunc) error
+	readFile(name string) ([]byte, error)
+	stat(name string) (fs.FileInfo, error)
+	writeFile(name string, data []byte) error
+	remove(name string) error
+
+	isAbs(name string) bool
+	join(elem ...string) string
+	dir(name string) string
+	rel(root, name string) (string, error)
+	// abs returns a name identifying the file regardless of how it's referenced.
+	abs(name string) (string, error)
+}
+
+// osFileSystem uses OS paths on the local disk.
+type osFileSystem struct{}
+
+var _ fileSystem = osFileSystem{}
+
+func (osFileSystem) walk(root string, fn fs.WalkDirFunc) error {
+	return filepath.WalkDir(root, fn)
+}
+
+func (osFileSystem) readFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFileSystem) stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFileSystem) writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0600)
+}
+
+func (osFileSystem) remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osFileSystem) isAbs(name string) bool {
+	return filepath.IsAbs(name)
+}
+
+func (osFileSystem) join(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+func (osFileSystem) dir(name string) string {
+	return filepath.Dir(name)
+}
+
+func (osFileSystem) rel(root, name string) (string, error) {
+	rel, err := filepath.Rel(root, name)
+	return filepath.ToSlash(rel), err
+}
+
+func (osFileSystem) abs(name string) (string, error) {
+	return filepath.Abs(name)
+}
+
+// overlayFileSystem is the local disk with the contents of the files in
+// overlay, keyed by absolute path, replaced.
+type overlayFileSystem struct {
+	osFileSystem
+	overlay map[string][]byte
+}
+
+var _ fileSystem = overlayFileSystem{}
+
+func (f overlayFileSystem) readFile(name string) ([]byte, error) {
+	if abs, err := filepath.Abs(name); err == nil {
+		if data, ok := f.overlay[abs]; ok {
+			return data, nil
+		}
+	}
+	return os.ReadFile(name)
+}
+
+// ioFileSystem uses slash separated paths within an fs.FS, it can only be
+// written to if the FS implements WriteFS.
+type ioFileSystem struct {
+	fsys fs.FS
+}
+
+var _ fileSystem = ioFileSystem{}
+
+// errReadOnly is returned when saving to an FS that doesn't implement WriteFS.
+var errReadOnly = errors.New("filesystem is read-only")
+
+func (f ioFileSystem) walk(root string, fn fs.WalkDirFunc) error {
+	return fs.WalkDir(f.fsys, root, fn)
+}
+
+func (f ioFileSystem) readFile(name string) ([]byte, error) {
+	return fs.ReadFile(f.fsys, name)
+}
+
+func (f ioFileSystem) stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(f.fsys, name)
+}
+
+func (f ioFileSystem) writeFile(name string, data []byte) error {
+	if writable, ok := f.fsys.(WriteFS); ok {
+		return writable.WriteFile(name, data)
+	}
+	return errReadOnly
+}
+
+func (f ioFileSystem) remove(name string) error {
+	if writable, ok := f.fsys.(WriteFS); ok {
+		return writable.Remove(name)
+	}
+	return errReadOnly
+}
+
+func (ioFileSystem) isAbs(name string) bool {
+	return path.IsAbs(name)
+}
+
+func (ioFileSystem) join(elem ...string) string {
+	return path.Join(elem...)
+}
+
+func (ioFileSystem) dir(name string) string {
+	return path.Dir(name)
+}
+
+func (ioFileSystem) rel(root, name string) (string, error) {
+	root, name = path.Clean(root), path.Clean(name)
+	if path.IsAbs(root) != path.IsAbs(name) {
+		return "", fmt.Errorf("can't make %s relative to %s", name, root)
+	}
+
+	elems := func(name string) []string {
+		if name == "." {
+			return nil
+		}
+		return strings.Split(name, "/")
+	}
+	rootElems, nameElems := elems(root), elems(name)
+
+	common := 0
+	for common < len(rootElems) && common < len(nameElems) && rootElems[common] == nameElems[common] {
+		common++
+	}
+
+	var out []string
+	for _, elem := range rootElems[common:] {
+		if elem == ".." {
+			return "", fmt.Errorf("can't make %s relative to %s", name, root)
+		}
+		out = append(out, "..")
+	}
+	out = append(out, nameElems[common:]...)
+
+	if len(out) == 0 {
+		return ".", nil
+	}
+	return strings.Join(out, "/"), nil
+}
+
+func (ioFileSystem) abs(name string) (string, error) {
+	return path.Clean(name), nil
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+)
+
+func mapFile(data string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(data)}
+}
+
+// testSuiteYAML returns a TestSuite with the name defining the exact
+// assertion, spec is appended to its spec.
+func testSuiteYAML(name, spec string) string {
+	return `apiVersion: compliancetest/v1
+kind: TestSuite
+metadata:
+  name: ` + name + `
+spec:
+  assertionConfig:
+    definitions:
+    - functionName: exact
+      inputSchema:
+        type: string
+      name: exact
+    script: |-
+      function exact(test) {
+        return {pass: {}};
+      }
+` + spec
+}
+
+func TestLoadSuiteFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"s/suite.yaml": mapFile(testSuiteYAML("suite", "")),
+		"s/specifications/spec.yaml": mapFile(`apiVersion: compliancetest/v1
+kind: Specification
+metadata:
+  name: spec
+sections:
+- metadata:
+    name: all
+  testSelector: suite=files
+`),
+		"s/tests/sub/misplaced.yaml": mapFile(`apiVersion: compliancetest/v1
+kind: Test
+metadata:
+  name: files
+  labels:
+    suite: files
+tests:
+- case:
+    uuid: 6a4ae7b3-8c3f-4e51-9b1f-6a3ad8f2d1c4
+    displayName: reads a fixture
+    input: (load "data.txt")
+    expect:
+      exact: data
+    files:
+    - name: data.txt
+      path: ../fixtures/data.txt
+`),
+		"s/tests/fixtures/data.txt": mapFile("data"),
+	}
+
+	suite, err := LoadSuiteFS(fsys, "s/")
+	if err != nil {
+		t.Fatalf("LoadSuiteFS() = %v", err)
+	}
+
+	suite.RunValidation(func(name string, v *validation.Validator) {
+		for _, result := range v.Results {
+			if result.Level == validation.LevelError {
+				t.Errorf("%s: %s", name, result.String())
+			}
+		}
+	})
+
+	tests := suite.ListTests()
+	if len(tests) != 1 {
+		t.Fatalf("ListTests() returned %d tests, want 1", len(tests))
+	}
+	if invalid := tests[0].GetInvalid(); invalid != nil {
+		t.Fatalf("ListTests() returned an invalid test: %s", invalid.GetMessage())
+	}
+	files := tests[0].GetEval().GetFiles()
+	if len(files) != 1 || files[0].GetContent() != "data" || files[0].GetPath() != "" {
+		t.Errorf("ListTests() files = %v, want data.txt with the fixture's content", files)
+	}
+
+	suite.Reorganize()
+	if got, want := suite.Tests[0].Path, "s/tests/files.yaml"; got != want {
+		t.Errorf("Reorganize() moved test to %q, want %q", got, want)
+	}
+	if got, want := suite.Tests[0].Value.Tests[0].Case.Files[0].Path, "fixtures/data.txt"; got != want {
+		t.Errorf("Reorganize() rewrote file path to %q, want %q", got, want)
+	}
+
+	if err := suite.Save(); !errors.Is(err, errReadOnly) {
+		t.Errorf("Save() = %v, want %v", err, errReadOnly)
+	}
+}
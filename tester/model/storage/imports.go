@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Import holds the hydrated tests and specifications of a suite or bundle
+// imported by the TestSuite. Imports are read-only, they're never tidied or
+// saved.
+type Import struct {
+	// Name of the import, prefixed to the UIDs of its tests, specifications and
+	// their sections.
+	Name string
+	// Path the import was loaded from.
+	Path string
+
+	Tests          []*executor.TestCase
+	Specifications []*executor.Specification
+}
+
+// loadImports loads the imports of the suite, chain holds the absolute paths
+// of the suites importing it to detect cycles.
+func (s *Suite) loadImports(chain []string) error {
+	for _, imp := range s.TestSuite.Value.Spec.Imports {
+		loaded, err := loadImport(s.fileSystem(), s.RootPath, imp, chain)
+		if err != nil {
+			return fmt.Errorf("couldn't import %q: %w", imp.Name, err)
+		}
+		s.Imports = append(s.Imports, *loaded)
+	}
+
+	return s.checkImportConflicts()
+}
+
+func loadImport(files fileSystem, root string, imp v1.TestSuiteSpecImport, chain []string) (*Import, error) {
+	path := imp.Path
+	if !files.isAbs(path) {
+		path = files.join(root, path)
+	}
+
+	absPath, err := files.abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, importer := range chain {
+		if importer == absPath {
+			return nil, fmt.Errorf("import cycle: %s", strings.Join(append(chain, absPath), " -> "))
+		}
+	}
+
+	info, err := files.stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var source executor.TestSuite
+	if info.IsDir() {
+		source, err = loadSuite(files, path, append(chain, absPath))
+	} else {
+		var data []byte
+		if data, err = files.readFile(path); err != nil {
+			return nil, err
+		}
+
+		if filepath.Ext(path) == ".zip" {
+			source, err = loadZip(absPath, data, chain)
+		} else {
+			source, err = executor.DecodeBundle(path, data)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.Everything()
+	if imp.TestSelector != "" {
+		if selector, err = labels.Parse(string(imp.TestSelector)); err != nil {
+			return nil, fmt.Errorf("invalid testSelector: %w", err)
+		}
+	}
+
+	out := &Import{
+		Name: imp.Name,
+		Path: path,
+	}
+
+	for _, spec := range source.ListSpecifications() {
+		spec = proto.Clone(spec).(*executor.Specification)
+		spec.Metadata.Uid = imp.Name + "/" + spec.Metadata.Uid
+		namespaceSections(imp.Name, spec.Sections)
+		out.Specifications = append(out.Specifications, spec)
+	}
+
+	for _, test := range source.ListTests() {
+		if !selector.Matches(labels.Set(test.GetMetadata().GetLabels())) {
+			continue
+		}
+
+		test = proto.Clone(test).(*executor.TestCase)
+		test.Metadata.Uid = imp.Name + "/" + test.Metadata.Uid
+		out.Tests = append(out.Tests, test)
+	}
+
+	return out, nil
+}
+
+// namespaceSections prefixes the UIDs of the sections and their subsections
+// with the name of the import.
+func namespaceSections(name string, sections []*executor.SpecificationSection) {
+	for _, section := range sections {
+		if section.Metadata != nil {
+			section.Metadata.Uid = name + "/" + section.Metadata.Uid
+		}
+		namespaceSections(name, section.GetSectionSummary().GetSubsections())
+	}
+}
+
+// checkImportConflicts returns an error if imports share a name, the UIDs
+// of their tests and specifications would clash otherwise.
+func (s *Suite) checkImportConflicts() error {
+	var errs []error
+
+	imports := make(map[string]bool)
+	for _, imp := range s.Imports {
+		if imports[imp.Name] {
+			errs = append(errs, fmt.Errorf("import %q is defined more than once", imp.Name))
+		}
+		imports[imp.Name] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateImports checks imported tests only use assertions the suite defines.
+func (s *Suite) validateImports(validators *suiteValidators) {
+	known := make(map[string]bool)
+	for _, defn := range s.TestSuite.Value.Spec.Assertions.Definitions {
+		known[defn.Name] = true
+	}
+
+	importsValidator := validators.testSuite.Field("spec").Field("imports")
+	for idx, imp := range s.Imports {
+		unknown := make(map[string]bool)
+		for _, test := range imp.Tests {
+			if expectation := test.GetEval().GetExpectationType(); expectation != "" && !known[expectation] {
+				unknown[expectation] = true
+			}
+		}
+
+		var names []string
+		for name := range unknown {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			importsValidator.AtIndex(idx).Error("imported tests use assertion %q which isn't defined by the suite", name)
+		}
+	}
+}
+
+// importedSpecificationNames returns the names of every imported specification.
+func (s *Suite) importedSpecificationNames() (out []string) {
+	for _, imp := range s.Imports {
+		for _, spec := range imp.Specifications {
+			out = append(out, spec.GetMetadata().GetUid())
+		}
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadSuiteFS_importUnderRoot(t *testing.T) {
+	fsys := fstest.MapFS{
+		"s/suite.yaml": mapFile(testSuiteYAML("suite", `  imports:
+  - name: shared
+    path: vendor-shared
+`)),
+		"s/vendor-shared/shared.yaml": mapFile(testSuiteYAML("shared", "")),
+		"s/vendor-shared/specifications/spec.yaml": mapFile(`apiVersion: compliancetest/v1
+kind: Specification
+metadata:
+  name: spec
+sections:
+- metadata:
+    name: all
+  testSelector: suite=shared
+`),
+		"s/vendor-shared/tests/shared.yaml": mapFile(`apiVersion: compliancetest/v1
+kind: Test
+metadata:
+  name: shared
+  labels:
+    suite: shared
+tests:
+- case:
+    uuid: 0f9d3b1e-2f4a-4c7e-8d6b-5a1c9e7f3b2d
+    displayName: shared case
+    input: "#t"
+    expect:
+      exact: "#t"
+`),
+	}
+
+	suite, err := LoadSuiteFS(fsys, "s")
+	if err != nil {
+		t.Fatalf("LoadSuiteFS() = %v", err)
+	}
+
+	if len(suite.Specifications) != 0 || len(suite.Tests) != 0 {
+		t.Errorf("suite has %d specs and %d tests of its own, want none", len(suite.Specifications), len(suite.Tests))
+	}
+	if len(suite.Imports) != 1 {
+		t.Fatalf("suite has %d imports, want 1", len(suite.Imports))
+	}
+
+	var got []string
+	for _, test := range suite.ListTests() {
+		got = append(got, test.GetMetadata().GetUid())
+	}
+	for _, spec := range suite.ListSpecifications() {
+		got = append(got, spec.GetMetadata().GetUid())
+		for _, section := range spec.GetSections() {
+			got = append(got, section.GetMetadata().GetUid())
+		}
+	}
+
+	want := []string{
+		"shared/0f9d3b1e-2f4a-4c7e-8d6b-5a1c9e7f3b2d",
+		"shared/spec",
+		"shared/all",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("imported UIDs (-want +got):\n%s", diff)
+	}
+}
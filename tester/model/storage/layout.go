@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"fmt"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+)
+
+// TestSuitePath returns the canonical location of the TestSuite with the given name.
+func (s *Suite) TestSuitePath(name string) string {
+	return s.fileSystem().join(s.RootPath, name+".yaml")
+}
+
+// ImplementationPath returns the canonical location of the Implementation
+// with the given name, each gets a directory for its supporting files.
+func (s *Suite) ImplementationPath(name string) string {
+	return s.fileSystem().join(s.RootPath, "implementations", name, name+".yaml")
+}
+
+// SpecificationPath returns the canonical location of the Specification with the given name.
+func (s *Suite) SpecificationPath(name string) string {
+	return s.fileSystem().join(s.RootPath, "specifications", name+".yaml")
+}
+
+// TestPath returns the canonical location of the Test with the given name.
+func (s *Suite) TestPath(name string) string {
+	return s.fileSystem().join(s.RootPath, "tests", name+".yaml")
+}
+
+// Reorganize moves each file to the canonical location for its kind and name.
+// Files without a name, whose location would be shared with another file, or
+// holding several documents are left in place. Relative paths tests read files
+// from are rewritten to match, tests are left in place if they can't be.
+func (s *Suite) Reorganize() {
+	files := s.fileSystem()
+	claimed := make(map[string]int)
+	claim := func(path string) {
+		claimed[files.join(path)]++
+	}
+	move := func(path *string, documents int, canonical string) {
+		if documents <= 1 && claimed[files.join(canonical)] == 1 {
+			*path = canonical
+		}
+	}
+
+	for _, impl := range s.Implementations {
+		claim(s.ImplementationPath(impl.Value.Metadata.Name))
+	}
+	for _, spec := range s.Specifications {
+		claim(s.SpecificationPath(spec.Value.Metadata.Name))
+	}
+	for _, test := range s.Tests {
+		claim(s.TestPath(test.Value.Metadata.Name))
+	}
+
+	if name := s.TestSuite.Value.Metadata.Name; name != "" {
+		claim(s.TestSuitePath(name))
+		move(&s.TestSuite.Path, s.TestSuite.documents, s.TestSuitePath(name))
+	}
+	for idx := range s.Implementations {
+		if file := &s.Implementations[idx]; file.Value.Metadata.Name != "" {
+			move(&file.Path, file.documents, s.ImplementationPath(file.Value.Metadata.Name))
+		}
+	}
+	for idx := range s.Specifications {
+		if file := &s.Specifications[idx]; file.Value.Metadata.Name != "" {
+			move(&file.Path, file.documents, s.SpecificationPath(file.Value.Metadata.Name))
+		}
+	}
+	for idx := range s.Tests {
+		file := &s.Tests[idx]
+		if file.Value.Metadata.Name == "" {
+			continue
+		}
+
+		from := file.Path
+		move(&file.Path, file.documents, s.TestPath(file.Value.Metadata.Name))
+		if err := s.moveTestFiles(&file.Value, from, file.Path); err != nil {
+			file.Path = from
+		}
+	}
+}
+
+// SplitTests moves every top level context after the first in each Test into
+// a new Test file, names are made unique by adding a numeric suffix. New files
+// are put next to the original if the paths their tests read files from can't
+// be rewritten.
+func (s *Suite) SplitTests() {
+	used := make(map[string]bool)
+	for _, test := range s.Tests {
+		used[test.Value.Metadata.Name] = true
+	}
+
+	var added []YamlFile[v1.Test]
+	for idx := range s.Tests {
+		for _, split := range s.Tests[idx].Value.SplitContexts() {
+			name := split.Metadata.Name
+			for n := 2; used[name]; n++ {
+				name = fmt.Sprintf("%s-%d", split.Metadata.Name, n)
+			}
+			used[name] = true
+			split.Metadata.Name = name
+
+			path := s.TestPath(name)
+			if err := s.moveTestFiles(&split, s.Tests[idx].Path, path); err != nil {
+				path = s.fileSystem().join(s.fileSystem().dir(s.Tests[idx].Path), name+".yaml")
+			}
+
+			file := NewYamlFile[v1.Test](path)
+			file.Value = split
+			// Keep the comments of the moved context.
+			file.base = s.Tests[idx].originalData
+			added = append(added, *file)
+		}
+	}
+
+	s.Tests = append(s.Tests, added...)
+}
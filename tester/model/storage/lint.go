@@ -0,0 +1,19 @@
+package storage
+
+import "github.com/josephlewis42/scheme-compliance/tester/lint"
+
+// lint runs the lint rules enabled by the suite against every file.
+func (s *Suite) lint(validators *suiteValidators) {
+	linter := lint.NewLinter(s.TestSuite.Value.Spec.Lint, validators.testSuite.Field("spec").Field("lint"))
+
+	linter.LintTestSuite(&s.TestSuite.Value, validators.testSuite)
+	for idx := range s.Implementations {
+		linter.LintImplementation(&s.Implementations[idx].Value, validators.implementations[idx])
+	}
+	for idx := range s.Specifications {
+		linter.LintSpecification(&s.Specifications[idx].Value, validators.specifications[idx])
+	}
+	for idx := range s.Tests {
+		linter.LintTest(&s.Tests[idx].Value, validators.tests[idx])
+	}
+}
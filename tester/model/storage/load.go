@@ -1,108 +1,359 @@
 package storage
 
 import (
+	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"io/fs"
-	"io/ioutil"
+	"os"
+	"path"
 	"path/filepath"
-	"sort"
+	"strings"
 
 	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
 	"sigs.k8s.io/yaml"
 )
 
-// LoadSuite loads a suite at the given path.
+// directoryKinds holds the kind of documents without one based on the
+// top level directory they're in, documents in the root are TestSuites. Only
+// documents with an apiVersion are given a kind this way.
+var directoryKinds = map[string]string{
+	".":               v1.KindTestSuite,
+	"implementations": v1.TypeImplementation,
+	"specifications":  v1.TypeSpecification,
+	"tests":           v1.KindTest,
+}
+
+// LoadSuite loads a suite at the given path, which may be a directory or a
+// .zip archive containing one.
+//
+// Every YAML or JSON file under the path is read, files may hold several
+// documents separated by "---" and each is decoded based on its kind.
+// Documents with neither a kind nor an apiVersion, like stored results, aren't
+// part of the suite.
+//
+// Suites imported by the TestSuite are loaded into Imports, the files of
+// imported suites under the path aren't part of the suite.
 func LoadSuite(path string) (*Suite, error) {
-	out := &Suite{
-		RootPath: path,
+	if filepath.Ext(path) == ".zip" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %s: %w", path, err)
+		}
+		return loadZip(path, data, nil)
 	}
-	var err error
-	var testSuites []YamlFile[v1.TestSuite]
 
-	testSuites, err = decode[v1.TestSuite](path, false)
-	switch {
-	case err != nil:
+	absPath, err := filepath.Abs(path)
+	if err != nil {
 		return nil, err
+	}
 
-	case len(testSuites) > 1:
-		return nil, errors.New("conflicting TestSuite definitions")
+	return loadSuite(osFileSystem{}, path, []string{absPath})
+}
 
-	case len(testSuites) == 0:
-		return nil, errors.New("missing TestSuite defintion")
+// LoadSuiteOverlay loads the suite in the directory at path like LoadSuite,
+// but files in overlay, keyed by absolute path, are read from it instead of
+// the disk. It's used to check unsaved edits.
+func LoadSuiteOverlay(path string, overlay map[string][]byte) (*Suite, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		out.TestSuite = testSuites[0]
+	return loadSuite(overlayFileSystem{overlay: overlay}, path, []string{absPath})
+}
+
+// LoadSuiteFS loads a suite rooted at the slash separated root directory of
+// the filesystem, see LoadSuite. Paths in the suite are relative to the
+// filesystem and it can only be saved if fsys implements WriteFS.
+func LoadSuiteFS(fsys fs.FS, root string) (*Suite, error) {
+	root = path.Clean(root)
+	return loadSuite(ioFileSystem{fsys}, root, []string{root})
+}
+
+// loadZip loads a suite from the .zip archive data. The suite may be at the
+// root of the archive or in its only top level directory.
+func loadZip(name string, data []byte, chain []string) (*Suite, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", name, err)
 	}
 
-	out.Implementations, err = decode[v1.Implementation](filepath.Join(path, "implementations"), true)
+	entries, err := fs.ReadDir(archive, ".")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("couldn't read %s: %w", name, err)
+	}
+
+	root := "."
+	if len(entries) == 1 && entries[0].IsDir() {
+		root = entries[0].Name()
+	}
+
+	return loadSuite(ioFileSystem{archive}, root, append(chain, name))
+}
+
+// loadSuite loads the suite at root, chain holds the absolute paths of the
+// suites importing it including itself.
+func loadSuite(files fileSystem, root string, chain []string) (*Suite, error) {
+	out := &Suite{
+		RootPath: root,
+		files:    files,
 	}
 
-	out.Tests, err = decode[v1.Test](filepath.Join(path, "tests"), true)
+	// Files of imported suites under the root belong to them.
+	imported, err := importRoots(files, root)
 	if err != nil {
 		return nil, err
 	}
 
-	out.Specifications, err = decode[v1.Specification](filepath.Join(path, "specifications"), true)
+	documents, err := readDocuments(files, root, imported)
 	if err != nil {
 		return nil, err
 	}
 
+	var testSuites []YamlFile[v1.TestSuite]
+	for _, doc := range documents {
+		var err error
+		switch doc.kind {
+		case v1.KindTestSuite:
+			testSuites, err = appendDocument(testSuites, doc)
+		case v1.TypeImplementation:
+			out.Implementations, err = appendDocument(out.Implementations, doc)
+		case v1.TypeSpecification:
+			out.Specifications, err = appendDocument(out.Specifications, doc)
+		case v1.KindTest:
+			out.Tests, err = appendDocument(out.Tests, doc)
+		default:
+			err = fmt.Errorf("couldn't decode %s: unknown kind %q", doc.name(), doc.kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case len(testSuites) > 1:
+		return nil, errors.New("conflicting TestSuite definitions")
+
+	case len(testSuites) == 0:
+		return nil, errors.New("missing TestSuite defintion")
+
+	default:
+		out.TestSuite = testSuites[0]
+	}
+
+	if err := out.loadImports(chain); err != nil {
+		return nil, err
+	}
+
 	return out, nil
 }
 
-func decode[T any](path string, recurse bool) ([]YamlFile[T], error) {
-	var results []YamlFile[T]
+// document is a single YAML document read from a file.
+type document struct {
+	path string
+	// index of the document in the file and the number of documents in it.
+	index, count int
+	// line the document starts on in the file, starting at 0.
+	line int
+	data []byte
+	kind string
+}
 
-	processedRoot := false
+// name returns the path of the document, including its index if the file
+// contains several.
+func (doc *document) name() string {
+	return documentName(doc.path, doc.index, doc.count)
+}
 
-	err := filepath.Walk(path, func(path string, info fs.FileInfo, err error) error {
+func documentName(path string, index, count int) string {
+	if count > 1 {
+		return fmt.Sprintf("%s[%d]", path, index)
+	}
+	return path
+}
+
+// importRoots returns the absolute paths of the suites imported by the
+// TestSuite documents in the root directory.
+func importRoots(files fileSystem, root string) (map[string]bool, error) {
+	out := make(map[string]bool)
+
+	err := files.walk(root, func(name string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
-			switch {
-			case recurse:
-				return nil
+		if entry.IsDir() {
+			if name != root {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		data, err := files.readFile(name)
+		if err != nil {
+			return fmt.Errorf("couldn't read %s: %w", name, err)
+		}
+
+		for _, docData := range SplitDocuments(data) {
+			// Errors are reported when the document is decoded.
+			var suite v1.TestSuite
+			if err := yaml.Unmarshal(docData.Data, &suite); err != nil || suite.Kind != v1.KindTestSuite {
+				continue
+			}
+
+			for _, imp := range suite.Spec.Imports {
+				path := imp.Path
+				if !files.isAbs(path) {
+					path = files.join(root, path)
+				}
+				if abs, err := files.abs(path); err == nil {
+					out[abs] = true
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+// readDocuments reads the documents of every suite file under root in a
+// deterministic order, skipping the files and directories in skip, keyed by
+// absolute path. Documents without a kind take the kind of the directory
+// they're in if they have an apiVersion, or are skipped.
+func readDocuments(files fileSystem, root string, skip map[string]bool) ([]document, error) {
+	var out []document
 
-			case !processedRoot:
-				processedRoot = true
-				return nil
+	err := files.walk(root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
 
-			default:
-				return filepath.SkipDir
+		if abs, err := files.abs(name); err == nil && skip[abs] && name != root {
+			if entry.IsDir() {
+				return fs.SkipDir
 			}
+			return nil
 		}
 
-		switch filepath.Ext(info.Name()) {
+		if entry.IsDir() {
+			if name != root && strings.HasPrefix(entry.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		switch filepath.Ext(entry.Name()) {
 		case ".yaml", ".yml", ".json":
-			var tmp T
-			bytes, err := ioutil.ReadFile(path)
-			if err != nil {
-				return fmt.Errorf("couldn't read %s: %e", path, err)
+		default:
+			return nil
+		}
+
+		data, err := files.readFile(name)
+		if err != nil {
+			return fmt.Errorf("couldn't read %s: %w", name, err)
+		}
+
+		rel, err := files.rel(root, name)
+		if err != nil {
+			return err
+		}
+		directoryKind := directoryKinds[strings.Split(path.Dir(rel), "/")[0]]
+
+		docs := SplitDocuments(data)
+		for idx, docData := range docs {
+			doc := document{
+				path:  name,
+				index: idx,
+				count: len(docs),
+				line:  docData.Line,
+				data:  docData.Data,
 			}
-			if err := yaml.UnmarshalStrict(bytes, &tmp); err != nil {
-				return fmt.Errorf("couldn't decode %s: %e", path, err)
+
+			var header v1.Version
+			if err := yaml.Unmarshal(doc.data, &header); err != nil {
+				return fmt.Errorf("couldn't decode %s: %w", doc.name(), err)
 			}
 
-			results = append(results, YamlFile[T]{
-				originalPath: path,
-				originalData: bytes,
-				Path:         path,
-				Value:        tmp,
-			})
+			doc.kind = header.Kind
+			if doc.kind == "" && header.APIVersion != "" {
+				doc.kind = directoryKind
+			}
+			if doc.kind != "" {
+				out = append(out, doc)
+			}
 		}
 
 		return nil
 	})
 
-	// Ensure inputs are deterministic.
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Path < results[i].Path
-	})
+	return out, err
+}
+
+// DocumentData is a single YAML document of a file.
+type DocumentData struct {
+	// Line the document starts on in the file, starting at 0.
+	Line int
+	Data []byte
+}
+
+// SplitDocuments splits data on "---" separator lines. Comments before a
+// separator are kept with the document after it and empty documents are
+// dropped.
+func SplitDocuments(data []byte) (out []DocumentData) {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+
+	start := 0
+	hasContent := false
+	flush := func(end int) {
+		if hasContent {
+			out = append(out, DocumentData{Line: start, Data: bytes.Join(lines[start:end], nil)})
+			start = end
+		}
+		hasContent = false
+	}
+
+	for idx, line := range lines {
+		trimmed := bytes.TrimRight(line, "\r\n")
+		switch {
+		case bytes.Equal(trimmed, []byte("---")) || bytes.HasPrefix(trimmed, []byte("--- ")):
+			flush(idx)
+			if start == idx {
+				// Leave the separator out of the document.
+				start = idx + 1
+			}
+		case len(bytes.TrimSpace(trimmed)) > 0 && !bytes.HasPrefix(bytes.TrimSpace(trimmed), []byte("#")):
+			hasContent = true
+		}
+	}
+	flush(len(lines))
+
+	return out
+}
+
+// appendDocument decodes the document as T and appends it to files.
+func appendDocument[T any](files []YamlFile[T], doc document) ([]YamlFile[T], error) {
+	var tmp T
+	if err := yaml.UnmarshalStrict(doc.data, &tmp); err != nil {
+		return nil, fmt.Errorf("couldn't decode %s: %w", doc.name(), err)
+	}
 
-	return results, err
+	return append(files, YamlFile[T]{
+		originalPath: doc.path,
+		originalData: doc.data,
+		document:     doc.index,
+		documents:    doc.count,
+		line:         doc.line,
+		Path:         doc.path,
+		Value:        tmp,
+	}), nil
 }
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadSuiteFS_unversionedFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"s/suite.yaml":               mapFile(testSuiteYAML("suite", "")),
+		"s/results.json":             mapFile(`{"results": []}`),
+		"s/tests/fixtures/data.json": mapFile(`{"values": [1, 2]}`),
+		"s/tests/kindless.yaml": mapFile(`apiVersion: compliancetest/v1
+metadata:
+  name: kindless
+tests: []
+`),
+	}
+
+	suite, err := LoadSuiteFS(fsys, "s")
+	if err != nil {
+		t.Fatalf("LoadSuiteFS() = %v", err)
+	}
+
+	if got, want := suite.TestSuite.Path, "s/suite.yaml"; got != want {
+		t.Errorf("LoadSuiteFS() TestSuite path = %q, want %q", got, want)
+	}
+	if len(suite.Tests) != 1 || suite.Tests[0].Path != "s/tests/kindless.yaml" {
+		t.Errorf("LoadSuiteFS() loaded %d tests, want only s/tests/kindless.yaml", len(suite.Tests))
+	}
+}
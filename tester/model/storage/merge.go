@@ -0,0 +1,320 @@
+package storage
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/josephlewis42/scheme-compliance/internal/yamlpath"
+	"gopkg.in/yaml.v3"
+)
+
+// mergeYAML returns updated with the comments, key order and scalar styles of
+// original kept wherever the values are unchanged. The original is returned
+// as-is if the two are equivalent, otherwise only the lines of entries that
+// changed are rewritten so blank lines and the formatting of everything else
+// are kept.
+func mergeYAML(original, updated []byte) ([]byte, error) {
+	var dst, src yaml.Node
+	if err := yaml.Unmarshal(original, &dst); err != nil || len(dst.Content) == 0 {
+		// Nothing worth keeping.
+		return updated, nil
+	}
+	if err := yaml.Unmarshal(updated, &src); err != nil {
+		return nil, err
+	}
+
+	if nodesEqual(&dst, &src) {
+		return original, nil
+	}
+
+	var orig yaml.Node
+	if err := yaml.Unmarshal(original, &orig); err != nil {
+		return nil, err
+	}
+
+	// Nodes without a position are new.
+	clearPositions(&src)
+	mergeNode(&dst, &src)
+
+	if patched, ok := patchLines(original, &orig, &dst); ok {
+		var check yaml.Node
+		if err := yaml.Unmarshal(patched, &check); err == nil && nodesEqual(&check, &src) {
+			return patched, nil
+		}
+	}
+
+	return encodeYAML(&dst, !indentsSequences(&orig))
+}
+
+// encodeYAML encodes the node, compact outdents block sequences to the level
+// of their key.
+func encodeYAML(node *yaml.Node, compact bool) ([]byte, error) {
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	if compact {
+		return compactSequences(out.Bytes())
+	}
+	return out.Bytes(), nil
+}
+
+func clearPositions(node *yaml.Node) {
+	node.Line, node.Column = 0, 0
+	for _, child := range node.Content {
+		clearPositions(child)
+	}
+}
+
+// indentsSequences returns true if the first block sequence found in a
+// mapping is indented past its key.
+func indentsSequences(node *yaml.Node) bool {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0 && value.Line > key.Line {
+				return value.Column > key.Column
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		if indentsSequences(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactSequences outdents block sequences in mappings to the level of
+// their key, the style used when marshaling with sigs.k8s.io/yaml.
+func compactSequences(data []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	outdent := make([]int, len(lines))
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode && node.Style&yaml.FlowStyle == 0 {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if value.Kind != yaml.SequenceNode || value.Style&yaml.FlowStyle != 0 || len(value.Content) == 0 {
+					continue
+				}
+
+				// The sequence continues until a line at or before the key's indentation.
+				keyIndent := key.Column - 1
+				for line := key.Line; line < len(lines); line++ {
+					text := lines[line]
+					if trimmed := bytes.TrimLeft(text, " "); len(trimmed) > 0 && len(text)-len(trimmed) <= keyIndent {
+						break
+					}
+					outdent[line] += value.Column - key.Column
+				}
+			}
+		}
+
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&root)
+
+	for idx, line := range lines {
+		if n := outdent[idx]; n > 0 && len(line) >= n && len(bytes.TrimLeft(line[:n], " ")) == 0 {
+			lines[idx] = line[n:]
+		}
+	}
+
+	return bytes.Join(lines, []byte("\n")), nil
+}
+
+// mergeNode updates dst in place to hold the value of src.
+func mergeNode(dst, src *yaml.Node) {
+	if dst.Kind != src.Kind {
+		head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+		return
+	}
+
+	switch dst.Kind {
+	case yaml.DocumentNode:
+		mergeNode(dst.Content[0], src.Content[0])
+
+	case yaml.MappingNode:
+		if len(dst.Content) == 0 {
+			// Empty mappings are written in flow style.
+			dst.Style = src.Style
+		}
+
+		srcValues := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			srcValues[src.Content[i].Value] = src.Content[i+1]
+		}
+
+		var content []*yaml.Node
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			key, value := dst.Content[i], dst.Content[i+1]
+			srcValue, ok := srcValues[key.Value]
+			if !ok {
+				continue
+			}
+			mergeNode(value, srcValue)
+			content = append(content, key, value)
+			seen[key.Value] = true
+		}
+
+		// New keys go after the existing ones, empty values are equivalent to
+		// missing ones so aren't worth adding.
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			value := src.Content[i+1]
+			isEmpty := (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) && len(value.Content) == 0
+			if !seen[src.Content[i].Value] && !isEmpty {
+				content = append(content, src.Content[i], src.Content[i+1])
+			}
+		}
+		dst.Content = content
+
+	case yaml.SequenceNode:
+		if len(dst.Content) == 0 {
+			dst.Style = src.Style
+		}
+
+		used := make([]bool, len(dst.Content))
+		var content []*yaml.Node
+		next := 0
+		for _, item := range src.Content {
+			match := matchItem(dst.Content, used, next, item)
+			if match < 0 {
+				content = append(content, item)
+				continue
+			}
+
+			used[match] = true
+			mergeNode(dst.Content[match], item)
+			content = append(content, dst.Content[match])
+			next = match + 1
+		}
+		dst.Content = content
+
+	case yaml.ScalarNode:
+		if nodesEqual(dst, src) {
+			return
+		}
+
+		dst.Tag, dst.Value = src.Tag, src.Value
+		switch {
+		case src.Tag != "!!str":
+			dst.Style = src.Style
+		case dst.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 && !bytes.ContainsRune([]byte(src.Value), '\n'):
+			// Block styles only suit multi-line strings.
+			dst.Style = src.Style
+		}
+
+	default:
+		*dst = *src
+	}
+}
+
+// matchItem returns the index of the unused item in dst that item should be
+// merged into, or -1 if there's none. Items with the same identity match
+// wherever they are, otherwise an equal item after next or the item at next
+// if it has no identity either.
+func matchItem(dst []*yaml.Node, used []bool, next int, item *yaml.Node) int {
+	ids := identities(item)
+	if len(ids) > 0 {
+		for idx, node := range dst {
+			if !used[idx] && sharesIdentity(ids, identities(node)) {
+				return idx
+			}
+		}
+	}
+
+	for idx := next; idx < len(dst); idx++ {
+		if !used[idx] && nodesEqual(dst[idx], item) {
+			return idx
+		}
+	}
+
+	if next < len(dst) && !used[next] && len(identities(dst[next])) == 0 {
+		return next
+	}
+	return -1
+}
+
+// identities returns the values identifying a sequence item: its uuid, name
+// or key, or the UUIDs of everything in a case or context.
+func identities(node *yaml.Node) []string {
+	for _, field := range []string{"uuid", "name", "key"} {
+		if _, value := yamlpath.Lookup(node, field); value != nil && value.Kind == yaml.ScalarNode && value.Value != "" {
+			return []string{field + ":" + value.Value}
+		}
+	}
+
+	_, metadata := yamlpath.Lookup(node, "metadata")
+	if _, name := yamlpath.Lookup(metadata, "name"); name != nil && name.Value != "" {
+		return []string{"metadata:" + name.Value}
+	}
+
+	for _, field := range []string{"case", "context"} {
+		if _, value := yamlpath.Lookup(node, field); value != nil {
+			var out []string
+			for _, uuid := range descendantUUIDs(value) {
+				out = append(out, field+":"+uuid)
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+func descendantUUIDs(node *yaml.Node) (out []string) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key, value := node.Content[i], node.Content[i+1]; key.Value == "uuid" && value.Kind == yaml.ScalarNode {
+				out = append(out, value.Value)
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		out = append(out, descendantUUIDs(child)...)
+	}
+	return
+}
+
+func sharesIdentity(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodesEqual returns true if the nodes decode to the same value.
+func nodesEqual(a, b *yaml.Node) bool {
+	var aValue, bValue any
+	if err := a.Decode(&aValue); err != nil {
+		return false
+	}
+	if err := b.Decode(&bValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+const mergeOriginal = `# Header comment
+kind: Test   # kind comment
+metadata:
+  name: a
+  description: >-
+    folded
+    text
+tests:
+- case:
+    uuid: first
+    input: "1"
+
+- case:
+    uuid: second
+    input: "2"
+`
+
+func TestMergeYAML(t *testing.T) {
+	cases := map[string]struct {
+		edit func(doc map[string]any)
+		want string
+	}{
+		"unchanged": {
+			edit: func(doc map[string]any) {},
+			want: mergeOriginal,
+		},
+		"changed scalar": {
+			edit: func(doc map[string]any) {
+				doc["metadata"].(map[string]any)["name"] = "b"
+			},
+			want: `# Header comment
+kind: Test   # kind comment
+metadata:
+  name: b
+  description: >-
+    folded
+    text
+tests:
+- case:
+    uuid: first
+    input: "1"
+
+- case:
+    uuid: second
+    input: "2"
+`,
+		},
+		"changed sequence item": {
+			edit: func(doc map[string]any) {
+				tests := doc["tests"].([]any)
+				tests[1].(map[string]any)["case"].(map[string]any)["input"] = "3"
+			},
+			want: `# Header comment
+kind: Test   # kind comment
+metadata:
+  name: a
+  description: >-
+    folded
+    text
+tests:
+- case:
+    uuid: first
+    input: "1"
+
+- case:
+    uuid: second
+    input: "3"
+`,
+		},
+		"added and removed items": {
+			edit: func(doc map[string]any) {
+				tests := doc["tests"].([]any)
+				doc["tests"] = []any{
+					tests[1],
+					map[string]any{"case": map[string]any{"uuid": "third", "input": "4"}},
+				}
+				doc["metadata"].(map[string]any)["labels"] = map[string]any{"lang": "scheme"}
+			},
+			want: `# Header comment
+kind: Test   # kind comment
+metadata:
+  name: a
+  description: >-
+    folded
+    text
+  labels:
+    lang: scheme
+tests:
+- case:
+    uuid: second
+    input: "2"
+- case:
+    input: "4"
+    uuid: third
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var doc map[string]any
+			if err := yaml.Unmarshal([]byte(mergeOriginal), &doc); err != nil {
+				t.Fatal(err)
+			}
+			tc.edit(doc)
+
+			updated, err := yaml.Marshal(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := mergeYAML([]byte(mergeOriginal), updated)
+			if err != nil {
+				t.Fatalf("mergeYAML() = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("mergeYAML() =\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package storage
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lineEdit replaces lines [start, end) with lines.
+type lineEdit struct {
+	start, end int
+	lines      []string
+}
+
+// linePatcher rewrites the lines of the original text holding entries that
+// changed, leaving the rest untouched.
+type linePatcher struct {
+	lines []string
+	// compact is set if block sequences are written at the level of their key.
+	compact bool
+	edits   []lineEdit
+}
+
+// patchLines returns the original text with the lines of the block mapping
+// entries and sequence items that differ in merged rewritten, nodes of merged
+// taken from the original keep their positions. It returns false if the
+// changes can't be made line by line.
+func patchLines(original []byte, orig, merged *yaml.Node) ([]byte, bool) {
+	if len(orig.Content) == 0 || len(merged.Content) == 0 {
+		return nil, false
+	}
+
+	p := &linePatcher{
+		lines:   strings.Split(string(original), "\n"),
+		compact: !indentsSequences(orig),
+	}
+	if !p.patch(orig.Content[0], merged.Content[0], 0, len(p.lines)) {
+		return nil, false
+	}
+
+	// Later edits first so earlier line numbers stay valid, replacements go
+	// before insertions at the same line.
+	sort.SliceStable(p.edits, func(i, j int) bool {
+		a, b := p.edits[i], p.edits[j]
+		if a.start != b.start {
+			return a.start > b.start
+		}
+		return a.end > b.end
+	})
+
+	lines := p.lines
+	for _, edit := range p.edits {
+		var next []string
+		next = append(next, lines[:edit.start]...)
+		next = append(next, edit.lines...)
+		next = append(next, lines[edit.end:]...)
+		lines = next
+	}
+
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+// patch records the edits turning the block collection orig, held in lines
+// [start, end), into merged. Nothing is recorded if it returns false.
+func (p *linePatcher) patch(orig, merged *yaml.Node, start, end int) bool {
+	if orig.Kind != merged.Kind || merged.Line == 0 || orig.Style&yaml.FlowStyle != 0 || merged.Style&yaml.FlowStyle != 0 {
+		return false
+	}
+
+	edits := len(p.edits)
+	ok := false
+	switch orig.Kind {
+	case yaml.MappingNode:
+		ok = p.patchMapping(orig, merged, start, end)
+	case yaml.SequenceNode:
+		ok = p.patchSequence(orig, merged, start, end)
+	}
+
+	if !ok {
+		p.edits = p.edits[:edits]
+	}
+	return ok
+}
+
+func (p *linePatcher) patchMapping(orig, merged *yaml.Node, start, end int) bool {
+	count := len(orig.Content) / 2
+	if count == 0 {
+		return false
+	}
+	indent := orig.Content[0].Column - 1
+
+	mergedEntries := make(map[string][2]*yaml.Node)
+	for i := 0; i+1 < len(merged.Content); i += 2 {
+		mergedEntries[merged.Content[i].Value] = [2]*yaml.Node{merged.Content[i], merged.Content[i+1]}
+	}
+
+	// Entries start at their head comments and end where the next one starts.
+	starts := make([]int, count+1)
+	origKeys := make(map[string]bool)
+	for i := 0; i < count; i++ {
+		key := orig.Content[2*i]
+		starts[i] = p.headStart(key.Line-1, start, indent)
+		origKeys[key.Value] = true
+	}
+	starts[count] = end
+
+	lastKept := -1
+	for i := 0; i < count; i++ {
+		key, value := orig.Content[2*i], orig.Content[2*i+1]
+		entry, ok := mergedEntries[key.Value]
+		if !ok {
+			p.edits = append(p.edits, lineEdit{start: starts[i], end: starts[i+1]})
+			continue
+		}
+		lastKept = i
+
+		if nodesEqual(value, entry[1]) {
+			continue
+		}
+		if value.Line > key.Line && p.patch(value, entry[1], key.Line, starts[i+1]) {
+			continue
+		}
+
+		first := key.Line - 1
+		p.replace(first, p.contentEnd(first, starts[i+1], indent, true), indent, mappingEntry(entry[0], entry[1], false))
+	}
+
+	var added []string
+	for i := 0; i+1 < len(merged.Content); i += 2 {
+		if !origKeys[merged.Content[i].Value] {
+			added = append(added, p.render(mappingEntry(merged.Content[i], merged.Content[i+1], true), indent, "")...)
+		}
+	}
+	if len(added) > 0 {
+		if lastKept < 0 {
+			return false
+		}
+		first := orig.Content[2*lastKept].Line - 1
+		at := p.contentEnd(first, starts[lastKept+1], indent, true)
+		p.edits = append(p.edits, lineEdit{start: at, end: at, lines: added})
+	}
+
+	return true
+}
+
+func (p *linePatcher) patchSequence(orig, merged *yaml.Node, start, end int) bool {
+	count := len(orig.Content)
+	if count == 0 {
+		return false
+	}
+	indent := orig.Column - 1
+
+	type position struct{ line, column int }
+	indexes := make(map[position]int)
+	dashes := make([]int, count)
+	starts := make([]int, count+1)
+	for i, item := range orig.Content {
+		indexes[position{item.Line, item.Column}] = i
+		dashes[i] = p.dashLine(item, start, indent)
+		starts[i] = p.headStart(dashes[i], start, indent)
+	}
+	starts[count] = end
+
+	// Items taken from the original must stay in order.
+	matches := make([]int, len(merged.Content))
+	matched := make([]bool, count)
+	previous := -1
+	for k, item := range merged.Content {
+		matches[k] = -1
+		if idx, ok := indexes[position{item.Line, item.Column}]; ok && item.Line > 0 {
+			if idx <= previous {
+				return false
+			}
+			matches[k], matched[idx], previous = idx, true, idx
+		}
+	}
+	if previous < 0 {
+		return false
+	}
+
+	for i := range orig.Content {
+		if !matched[i] {
+			p.edits = append(p.edits, lineEdit{start: starts[i], end: starts[i+1]})
+		}
+	}
+
+	inserts := make(map[int][]string)
+	var positions []int
+	for k, item := range merged.Content {
+		idx := matches[k]
+		if idx >= 0 {
+			if nodesEqual(orig.Content[idx], item) || p.patch(orig.Content[idx], item, dashes[idx], starts[idx+1]) {
+				continue
+			}
+			first := dashes[idx]
+			p.replace(first, p.contentEnd(first, starts[idx+1], indent, false), indent, sequenceItem(item, false))
+			continue
+		}
+
+		// New items go before the next original item, or after the last.
+		at := -1
+		for _, next := range matches[k+1:] {
+			if next >= 0 {
+				at = starts[next]
+				break
+			}
+		}
+		if at < 0 {
+			at = p.contentEnd(dashes[previous], starts[previous+1], indent, false)
+		}
+
+		if _, ok := inserts[at]; !ok {
+			positions = append(positions, at)
+		}
+		inserts[at] = append(inserts[at], p.render(sequenceItem(item, true), indent, "")...)
+	}
+
+	for _, at := range positions {
+		p.edits = append(p.edits, lineEdit{start: at, end: at, lines: inserts[at]})
+	}
+
+	return true
+}
+
+// replace records rewriting lines [first, end) with the node, the text before
+// indent on the first line is kept.
+func (p *linePatcher) replace(first, end, indent int, node *yaml.Node) {
+	prefix := ""
+	if line := p.lines[first]; len(line) >= indent {
+		prefix = line[:indent]
+	}
+	p.edits = append(p.edits, lineEdit{start: first, end: end, lines: p.render(node, indent, prefix)})
+}
+
+// render encodes the node indented to the column, the first line starts with
+// prefix instead if it's set.
+func (p *linePatcher) render(node *yaml.Node, indent int, prefix string) []string {
+	out, err := encodeYAML(node, p.compact)
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(string(bytes.TrimRight(out, "\n")), "\n")
+	padding := strings.Repeat(" ", indent)
+	for idx, line := range lines {
+		switch {
+		case idx == 0 && prefix != "":
+			lines[idx] = prefix + line
+		case line != "":
+			lines[idx] = padding + line
+		}
+	}
+	return lines
+}
+
+// headStart returns the first line of the comments directly above line at
+// the indentation, stopping at min.
+func (p *linePatcher) headStart(line, min, indent int) int {
+	for line > min {
+		text := p.lines[line-1]
+		trimmed := strings.TrimLeft(text, " ")
+		if !strings.HasPrefix(trimmed, "#") || len(text)-len(trimmed) != indent {
+			break
+		}
+		line--
+	}
+	return line
+}
+
+// contentEnd returns the line after the last one of the entry or item
+// starting at first, lines belong to it while they're indented past indent.
+// Mapping entries also hold sequences at their own level.
+func (p *linePatcher) contentEnd(first, end, indent int, mapping bool) int {
+	last := first
+	for line := first + 1; line < end; line++ {
+		text := strings.TrimRight(p.lines[line], "\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" {
+			continue
+		}
+
+		depth := len(text) - len(trimmed)
+		if depth < indent || depth == indent && !(mapping && strings.HasPrefix(trimmed, "-")) {
+			break
+		}
+		last = line
+	}
+	return last + 1
+}
+
+// dashLine returns the line holding the dash of the sequence item.
+func (p *linePatcher) dashLine(item *yaml.Node, min, indent int) int {
+	for line := item.Line - 1; line >= min; line-- {
+		if text := p.lines[line]; len(text) > indent && text[indent] == '-' {
+			return line
+		}
+	}
+	return item.Line - 1
+}
+
+// mappingEntry returns a mapping holding only the entry, comments around
+// existing entries are left in place so aren't included.
+func mappingEntry(key, value *yaml.Node, added bool) *yaml.Node {
+	keyCopy, valueCopy := *key, *value
+	if !added {
+		keyCopy.HeadComment, keyCopy.FootComment = "", ""
+		valueCopy.FootComment = ""
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{&keyCopy, &valueCopy}}
+}
+
+// sequenceItem returns a sequence holding only the item, comments around
+// existing items are left in place so aren't included.
+func sequenceItem(item *yaml.Node, added bool) *yaml.Node {
+	itemCopy := *item
+	if !added {
+		itemCopy.HeadComment, itemCopy.FootComment = "", ""
+	}
+	return &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{&itemCopy}}
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"github.com/josephlewis42/scheme-compliance/internal/yamlpath"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// resolvePositions sets the file of each result and its line and column in
+// the file's original contents.
+func (file *YamlFile[T]) resolvePositions(v *validation.Validator) {
+	var document yaml.Node
+	var root *yaml.Node
+	if err := yaml.Unmarshal(file.originalData, &document); err == nil && len(document.Content) > 0 {
+		root = document.Content[0]
+	}
+
+	for idx := range v.Results {
+		result := &v.Results[idx]
+		result.File = file.Path
+
+		if node := yamlpath.Find(root, result.Field); node != nil {
+			result.Line = node.Line + file.line
+			result.Column = node.Column
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// suiteValidators holds the validator of each file in the suite in load order.
+type suiteValidators struct {
+	testSuite       *validation.Validator
+	implementations []*validation.Validator
+	specifications  []*validation.Validator
+	tests           []*validation.Validator
+}
+
+// validateReferences runs checks that span multiple files, findings are added
+// to the validator of the file that caused them.
+func (s *Suite) validateReferences(validators *suiteValidators) {
+	s.validateUniqueNames(validators)
+	s.validateUniqueUUIDs(validators)
+	s.validateSpecificationReferences(validators)
+	s.validateAssertionReferences(validators)
+	s.validateExpectationInputs(validators)
+	s.validateExclusions(validators)
+	s.validateTestFiles(validators)
+	s.validateLabelTaxonomy(validators)
+	s.validateImports(validators)
+}
+
+// definitions records the location each value was first seen at.
+type definitions map[string]string
+
+// add records the location of the value or reports an error if it was seen before.
+func (d definitions) add(validator *validation.Validator, what, value, location string) {
+	if existing, ok := d[value]; ok {
+		validator.Error("%s %q is already used in %s", what, value, existing)
+		return
+	}
+	d[value] = location
+}
+
+func fieldLocation(path string, validator *validation.Validator) string {
+	return fmt.Sprintf("%s:%s", path, strings.TrimPrefix(validator.Prefix, "."))
+}
+
+func (s *Suite) validateUniqueNames(validators *suiteValidators) {
+	implementations := make(definitions)
+	for idx, impl := range s.Implementations {
+		implementations.add(validators.implementations[idx].Field("metadata").Field("name"), "name", impl.Value.Metadata.Name, impl.Path)
+	}
+
+	specifications := make(definitions)
+	for idx, spec := range s.Specifications {
+		specifications.add(validators.specifications[idx].Field("metadata").Field("name"), "name", spec.Value.Metadata.Name, spec.Path)
+	}
+
+	tests := make(definitions)
+	for idx, test := range s.Tests {
+		tests.add(validators.tests[idx].Field("metadata").Field("name"), "name", test.Value.Metadata.Name, test.Path)
+	}
+}
+
+func (s *Suite) validateUniqueUUIDs(validators *suiteValidators) {
+	uuids := make(definitions)
+	for idx, test := range s.Tests {
+		path := test.Path
+		test.Value.WalkCaseFields(validators.tests[idx], func(validator *validation.Validator, tc *v1.TestCase, _ v1.HydratedTestCaseTemplate) {
+			if tc.UUID != nil {
+				uuids.add(validator.Field("uuid"), "UUID", *tc.UUID, fieldLocation(path, validator))
+			}
+		})
+	}
+}
+
+func (s *Suite) validateSpecificationReferences(validators *suiteValidators) {
+	known := make(map[string]bool)
+	for _, spec := range s.Specifications {
+		known[spec.Value.Metadata.Name] = true
+	}
+	for _, name := range s.importedSpecificationNames() {
+		known[name] = true
+	}
+
+	for idx, impl := range s.Implementations {
+		variantsValidator := validators.implementations[idx].Field("variants")
+		for variantIdx, variant := range impl.Value.Variants {
+			specsValidator := variantsValidator.AtIndex(variantIdx).Field("specifications")
+			for specIdx, name := range variant.Specifications {
+				if !known[name] {
+					specsValidator.AtIndex(specIdx).Error("unknown specification %q", name)
+				}
+			}
+		}
+	}
+}
+
+func (s *Suite) validateAssertionReferences(validators *suiteValidators) {
+	known := make(map[string]bool)
+	for _, defn := range s.TestSuite.Value.Spec.Assertions.Definitions {
+		known[defn.Name] = true
+	}
+
+	for idx, test := range s.Tests {
+		test.Value.ValidateAssertions(validators.tests[idx], known)
+	}
+}
+
+// validateExpectationInputs checks the effective expectation of every case
+// against the input schema of its assertion.
+func (s *Suite) validateExpectationInputs(validators *suiteValidators) {
+	runtime, err := s.TestSuite.Value.Spec.Assertions.CreateRuntime()
+	if err != nil {
+		// Reported when validating the suite.
+		return
+	}
+
+	for idx, test := range s.Tests {
+		test.Value.WalkCaseFields(validators.tests[idx], func(validator *validation.Validator, tc *v1.TestCase, parent v1.HydratedTestCaseTemplate) {
+			expect := tc.EffectiveExpect(parent)
+			if tc.IsSkipped() || expect == nil || len(*expect) != 1 {
+				// Skipped tests aren't run and other problems are reported by the test.
+				return
+			}
+
+			inherited := tc.Expect == nil
+			if !inherited {
+				validator = validator.Field("expect")
+			}
+
+			for expectationType, options := range *expect {
+				inputErrors, err := runtime.CheckExpectationInput(expectationType, string(options))
+				if err != nil {
+					// Unknown assertion types are reported by validateAssertionReferences.
+					continue
+				}
+
+				for _, inputError := range inputErrors {
+					fieldValidator, message := validator, inputError.Message
+					if inherited {
+						message = fmt.Sprintf("inherited expectation %q: %s", expectationType, inputError.String())
+					} else {
+						fieldValidator = fieldValidator.Field(expectationType)
+						if inputError.Field != "" {
+							for _, part := range strings.Split(inputError.Field, ".") {
+								fieldValidator = fieldValidator.Field(part)
+							}
+						}
+					}
+
+					fieldValidator.Error("%s", message)
+				}
+			}
+		})
+	}
+}
+
+// validateExclusions warns about sections selecting tests their specification excludes.
+func (s *Suite) validateExclusions(validators *suiteValidators) {
+	type testLabels struct {
+		uid    string
+		labels labels.Set
+	}
+
+	var tests []testLabels
+	for _, test := range s.Tests {
+		test.Value.WalkEffectiveCases(func(tc *v1.TestCase, parent v1.HydratedTestCaseTemplate) {
+			uid := parent.Path
+			if tc.UUID != nil {
+				uid = *tc.UUID
+			}
+			tests = append(tests, testLabels{uid, labels.Set(tc.Labels.MergeOver(parent.Labels))})
+		})
+	}
+
+	for idx, spec := range s.Specifications {
+		if spec.Value.ExclusionTestSelector == "" {
+			continue
+		}
+
+		exclusion, err := labels.Parse(string(spec.Value.ExclusionTestSelector))
+		if err != nil {
+			// Reported when validating the file.
+			continue
+		}
+
+		spec.Value.WalkTestSelectors(validators.specifications[idx], func(validator *validation.Validator, rawSelector v1.LabelSelector) {
+			selector, err := labels.Parse(string(rawSelector))
+			if err != nil {
+				return
+			}
+
+			var overlapping []string
+			for _, test := range tests {
+				if selector.Matches(test.labels) && exclusion.Matches(test.labels) {
+					overlapping = append(overlapping, test.uid)
+				}
+			}
+
+			if len(overlapping) > 0 {
+				validator.Warning(
+					"selects %d tests excluded by exclusionTestSelector %q, e.g. %q",
+					len(overlapping),
+					spec.Value.ExclusionTestSelector,
+					overlapping[0],
+				)
+			}
+		})
+	}
+}
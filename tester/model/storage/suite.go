@@ -1,8 +1,11 @@
 package storage
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/josephlewis42/scheme-compliance/tester/executor"
 	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
@@ -12,43 +15,69 @@ import (
 // Suite represents the full set of files that make up a test suite on-disk.
 type Suite struct {
 	RootPath string
+	files    fileSystem
 
 	TestSuite       YamlFile[v1.TestSuite]
 	Implementations []YamlFile[v1.Implementation]
 	Specifications  []YamlFile[v1.Specification]
 	Tests           []YamlFile[v1.Test]
+
+	// Imports are read-only, their tests and specifications are included
+	// when listing the suite's.
+	Imports []Import
 }
 
-func (s *Suite) RunValidation(callback func(name string, v *validation.Validator)) {
-	{
-		v := &validation.Validator{}
-		s.TestSuite.Value.Validate(v)
-		callback(s.TestSuite.Path, v)
+// fileSystem returns the filesystem the suite was loaded from, the local disk
+// if it wasn't loaded.
+func (s *Suite) fileSystem() fileSystem {
+	if s.files == nil {
+		return osFileSystem{}
 	}
+	return s.files
+}
+
+// RunValidation validates each file, the references between them and the
+// enabled lint rules, callback is executed with the results of each file
+// resolved to positions in the file.
+func (s *Suite) RunValidation(callback func(name string, v *validation.Validator)) {
+	validators := &suiteValidators{testSuite: &validation.Validator{}}
+	s.TestSuite.Value.Validate(validators.testSuite)
 
 	for _, impl := range s.Implementations {
 		v := &validation.Validator{}
 		impl.Value.Validate(v)
-		callback(impl.Path, v)
+		validators.implementations = append(validators.implementations, v)
 	}
 
 	for _, spec := range s.Specifications {
 		v := &validation.Validator{}
 		spec.Value.Validate(v)
-		callback(spec.Path, v)
+		validators.specifications = append(validators.specifications, v)
 	}
 
 	for _, test := range s.Tests {
 		v := &validation.Validator{}
 		test.Value.Validate(v)
-		callback(test.Path, v)
+		validators.tests = append(validators.tests, v)
 	}
 
-	// TODO: Add a global validation
-
-	// Each item should have a unique name
+	s.validateReferences(validators)
+	s.lint(validators)
 
-	// Exclusions should not overlap with inclusions
+	s.TestSuite.resolvePositions(validators.testSuite)
+	callback(s.TestSuite.name(), validators.testSuite)
+	for idx, impl := range s.Implementations {
+		impl.resolvePositions(validators.implementations[idx])
+		callback(impl.name(), validators.implementations[idx])
+	}
+	for idx, spec := range s.Specifications {
+		spec.resolvePositions(validators.specifications[idx])
+		callback(spec.name(), validators.specifications[idx])
+	}
+	for idx, test := range s.Tests {
+		test.resolvePositions(validators.tests[idx])
+		callback(test.name(), validators.tests[idx])
+	}
 }
 
 // Tidy cleans up the structure to remove validation warnings.
@@ -68,21 +97,157 @@ func (s *Suite) Tidy() {
 	}
 }
 
-// Save updates the files on disk.
-func (s *Suite) Save() {
-	s.TestSuite.Save()
+// Save updates the files on disk. Every file is written before moved files
+// are removed so files can take each other's places. Files holding several
+// documents are written with the documents in their original order. Files
+// that fail to write aren't removed, the returned error holds every failure.
+func (s *Suite) Save() error {
+	files := s.fileSystem()
 
-	for _, impl := range s.Implementations {
-		impl.Save()
+	// Group documents by the file they're written to.
+	var paths []string
+	byPath := make(map[string][]suiteDocument)
+	originalCounts := make(map[string]int)
+	for _, doc := range s.documents() {
+		path, originalPath, _ := doc.location()
+		if originalPath != "" {
+			originalCounts[files.join(originalPath)]++
+		}
+		if path == "" {
+			continue
+		}
+		path = files.join(path)
+		if _, ok := byPath[path]; !ok {
+			paths = append(paths, path)
+		}
+		byPath[path] = append(byPath[path], doc)
 	}
 
-	for _, spec := range s.Specifications {
-		spec.Save()
+	var errs []error
+	keep := make(map[string]bool)
+	for _, path := range paths {
+		keep[path] = true
+		group := byPath[path]
+		if err := saveDocuments(files, path, group, originalCounts[path]); err != nil {
+			errs = append(errs, err)
+			for _, doc := range group {
+				_, originalPath, _ := doc.location()
+				keep[files.join(originalPath)] = true
+			}
+		}
+	}
+
+	for original := range originalCounts {
+		if keep[original] {
+			continue
+		}
+		if err := files.remove(original); err != nil {
+			errs = append(errs, fmt.Errorf("couldn't remove %s: %w", original, err))
+			continue
+		}
+		// Clean up directories left empty, fails if they aren't.
+		files.remove(files.dir(original))
+	}
+
+	return errors.Join(errs...)
+}
+
+// saveDocuments writes the documents to path if any changed, originalCount is
+// the number of documents the file held.
+func saveDocuments(files fileSystem, path string, docs []suiteDocument, originalCount int) error {
+	// Documents already in the file keep their order, new ones go at the end.
+	position := func(doc suiteDocument) int {
+		_, originalPath, document := doc.location()
+		if files.join(originalPath) != path {
+			return originalCount
+		}
+		return document
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		return position(docs[i]) < position(docs[j])
+	})
+
+	changed := len(docs) != originalCount
+	var contents [][]byte
+	for _, doc := range docs {
+		data, docChanged, err := doc.render()
+		if err != nil {
+			return err
+		}
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+		contents = append(contents, data)
+		changed = changed || docChanged
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := files.writeFile(path, bytes.Join(contents, []byte("---\n"))); err != nil {
+		return fmt.Errorf("couldn't write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// suiteDocument is implemented by every YamlFile regardless of its type.
+type suiteDocument interface {
+	// location returns the current path, original path and index of the document.
+	location() (path, originalPath string, document int)
+	// render marshals the document and reports if it differs from the original.
+	render() (data []byte, changed bool, err error)
+}
+
+func (file *YamlFile[T]) location() (string, string, int) {
+	return file.Path, file.originalPath, file.document
+}
+
+func (file *YamlFile[T]) render() ([]byte, bool, error) {
+	data, err := file.marshal()
+	if err != nil {
+		return nil, false, fmt.Errorf("couldn't marshal %s: %w", file.Path, err)
+	}
+
+	return data, file.moved() || !bytes.Equal(data, file.originalData), nil
+}
+
+// documents returns every document in the suite.
+func (s *Suite) documents() []suiteDocument {
+	out := []suiteDocument{&s.TestSuite}
+	for idx := range s.Implementations {
+		out = append(out, &s.Implementations[idx])
+	}
+	for idx := range s.Specifications {
+		out = append(out, &s.Specifications[idx])
+	}
+	for idx := range s.Tests {
+		out = append(out, &s.Tests[idx])
+	}
+	return out
+}
+
+// SourceKinds returns the kinds of the documents loaded from each file.
+func (s *Suite) SourceKinds() map[string][]string {
+	out := make(map[string][]string)
+	add := func(path, kind string) {
+		if path != "" {
+			out[path] = append(out[path], kind)
+		}
 	}
 
+	add(s.TestSuite.originalPath, v1.KindTestSuite)
+	for _, impl := range s.Implementations {
+		add(impl.originalPath, v1.TypeImplementation)
+	}
+	for _, spec := range s.Specifications {
+		add(spec.originalPath, v1.TypeSpecification)
+	}
 	for _, test := range s.Tests {
-		test.Save()
+		add(test.originalPath, v1.KindTest)
 	}
+	return out
 }
 
 // Diff returns a human-readable diff for the whole suite compared to its stored version.
@@ -106,10 +271,14 @@ func (s *Suite) Diff(w io.Writer) {
 func (s *Suite) ListTests() (hydrated []*executor.TestCase) {
 	for _, t := range s.Tests {
 		t.Value.WalkCases(func(test *executor.TestCase) {
-			hydrated = append(hydrated, test)
+			hydrated = append(hydrated, s.readTestFiles(t.Path, test))
 		})
 	}
 
+	for _, imp := range s.Imports {
+		hydrated = append(hydrated, imp.Tests...)
+	}
+
 	return
 }
 
@@ -125,9 +294,24 @@ func (s *Suite) ListSpecifications() (hydrated []*executor.Specification) {
 	for _, spec := range s.Specifications {
 		hydrated = append(hydrated, spec.Value.ConvertToInternal())
 	}
+
+	for _, imp := range s.Imports {
+		hydrated = append(hydrated, imp.Specifications...)
+	}
 	return
 }
 
 func (s *Suite) TestAssertionEngine() (*executor.Runtime, error) {
 	return s.TestSuite.Value.Spec.Assertions.CreateRuntime()
 }
+
+// Bundle creates a self-contained bundle of the hydrated suite.
+func (s *Suite) Bundle() *executor.Bundle {
+	return &executor.Bundle{
+		Metadata:        s.TestSuite.Value.Metadata.ConvertToInternal(),
+		AssertionConfig: s.TestSuite.Value.Spec.Assertions.ConvertToInternal(),
+		Specifications:  s.ListSpecifications(),
+		Implementations: s.ListImplementations(),
+		Tests:           s.ListTests(),
+	}
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+	"github.com/josephlewis42/scheme-compliance/tester/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// taxonomy holds the allowed values of each declared label key, a nil set
+// allows any value.
+type taxonomy map[string]map[string]bool
+
+func newTaxonomy(declared []v1.TestSuiteSpecLabel) taxonomy {
+	out := make(taxonomy)
+	for _, label := range declared {
+		var values map[string]bool
+		for _, value := range label.Values {
+			if values == nil {
+				values = make(map[string]bool)
+			}
+			values[value.Value] = true
+		}
+		out[label.Key] = values
+	}
+	return out
+}
+
+// checkLabel reports a key that isn't declared or a value it doesn't allow.
+func (t taxonomy) checkLabel(validator *validation.Validator, key, value string) {
+	values, ok := t[key]
+	switch {
+	case !ok:
+		validator.Error("undeclared label key %q%s", key, suggest(key, keys(t)))
+	case values != nil && !values[value]:
+		validator.Error("value %q isn't declared for label %q%s", value, key, suggest(value, keys(values)))
+	}
+}
+
+// validateLabelTaxonomy checks labels and selectors only use the label keys
+// and values declared by the suite.
+func (s *Suite) validateLabelTaxonomy(validators *suiteValidators) {
+	if len(s.TestSuite.Value.Spec.Labels) == 0 {
+		return
+	}
+	declared := newTaxonomy(s.TestSuite.Value.Spec.Labels)
+
+	checkLabels := func(validator *validation.Validator, labels v1.Labels) {
+		for _, key := range keys(labels) {
+			declared.checkLabel(validator.AtKey(key), key, labels[key])
+		}
+	}
+
+	checkSelector := func(validator *validation.Validator, selector v1.LabelSelector) {
+		parsed, err := labels.Parse(string(selector))
+		if err != nil {
+			// Reported when validating the file.
+			return
+		}
+
+		requirements, _ := parsed.Requirements()
+		for _, requirement := range requirements {
+			switch requirement.Operator() {
+			case selection.Exists, selection.DoesNotExist:
+				if _, ok := declared[requirement.Key()]; !ok {
+					declared.checkLabel(validator, requirement.Key(), "")
+				}
+			default:
+				for _, value := range requirement.Values().List() {
+					declared.checkLabel(validator, requirement.Key(), value)
+				}
+			}
+		}
+	}
+
+	s.TestSuite.Value.WalkLabels(validators.testSuite, checkLabels)
+
+	for idx := range s.Implementations {
+		impl := &s.Implementations[idx].Value
+		impl.WalkLabels(validators.implementations[idx], checkLabels)
+		impl.WalkTestSelectors(validators.implementations[idx], checkSelector)
+	}
+
+	for idx := range s.Specifications {
+		spec := &s.Specifications[idx].Value
+		spec.WalkLabels(validators.specifications[idx], checkLabels)
+		spec.WalkTestSelectors(validators.specifications[idx], checkSelector)
+		if spec.ExclusionTestSelector != "" {
+			checkSelector(validators.specifications[idx].Field("exclusionTestSelector"), spec.ExclusionTestSelector)
+		}
+	}
+
+	for idx := range s.Tests {
+		s.Tests[idx].Value.WalkLabels(validators.tests[idx], checkLabels)
+	}
+}
+
+func keys[V any](m map[string]V) (out []string) {
+	for key := range m {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return
+}
+
+// suggest returns a hint naming the closest candidate to value, or an empty
+// string if none are close.
+func suggest(value string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := editDistance(value, candidate)
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	// Allow roughly one edit for every three characters.
+	if bestDistance < 0 || bestDistance > len(value)/3+1 {
+		return ""
+	}
+
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if deletion := previous[j] + 1; deletion < current[j] {
+				current[j] = deletion
+			}
+			if insertion := current[j-1] + 1; insertion < current[j] {
+				current[j] = insertion
+			}
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -37,16 +39,34 @@ func OpenYamlFile[T any](path string) (*YamlFile[T], error) {
 }
 
 // YamlFile maps an on-disk file with a specific parsed value from that file.
+// Files holding several documents have a YamlFile for each.
 type YamlFile[T any] struct {
 	originalPath string
 	originalData []byte
+	// document is the index of the document in the original file, documents is
+	// the number of documents in it and line is the line it starts on.
+	document, documents, line int
+	// base holds YAML whose comments and styles are kept when marshaling a
+	// file without original data, e.g. one split from another.
+	base []byte
 
 	Path  string
 	Value T
 }
 
+// marshal encodes the value, keeping the comments, key order and styles of
+// the original data where possible.
 func (file *YamlFile[T]) marshal() ([]byte, error) {
-	return yaml.Marshal(file.Value)
+	out, err := yaml.Marshal(file.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	original := file.originalData
+	if original == nil {
+		original = file.base
+	}
+	return mergeYAML(original, out)
 }
 
 // Diff returns a human-readable diff of the file compared to its stored version.
@@ -58,9 +78,9 @@ func (file *YamlFile[T]) Diff() string {
 	case file.originalPath == "":
 		pathDiff = fmt.Sprintf("new file: %s", file.Path)
 	case file.Path == "":
-		pathDiff = fmt.Sprintf("deleted: %s", file.originalPath)
+		pathDiff = fmt.Sprintf("deleted: %s", file.name())
 	case file.originalPath != file.Path:
-		pathDiff = fmt.Sprintf("renamed: %q -> %q", file.originalPath, file.Path)
+		pathDiff = fmt.Sprintf("renamed: %q -> %q", documentName(file.originalPath, file.document, file.documents), file.Path)
 	}
 
 	// Coalesce data into the same type for diffing
@@ -91,7 +111,7 @@ func (file *YamlFile[T]) Diff() string {
 		return fmt.Sprintf("%s\n [file contents match]\n", pathDiff)
 
 	case pathDiff == "": // Content only change
-		return fmt.Sprintf("modified: %s\n%s\n", file.Path, delta)
+		return fmt.Sprintf("modified: %s\n%s\n", file.name(), delta)
 
 	default: // Content and path change
 		return fmt.Sprintf("%s\n%s\n", pathDiff, delta)
@@ -100,20 +120,57 @@ func (file *YamlFile[T]) Diff() string {
 
 // Save updates the file on disk.
 func (file *YamlFile[T]) Save() error {
-	// Write new file.
-	if file.Path != "" {
-		newContents, err := file.marshal()
-		if err != nil {
-			return fmt.Errorf("couldn't marshal file: %e", err)
+	if err := file.write(); err != nil {
+		return err
+	}
+
+	// File was deleted or moved.
+	if file.moved() {
+		if err := os.Remove(file.originalPath); err != nil {
+			return fmt.Errorf("couldn't remove %s: %w", file.originalPath, err)
 		}
+	}
+
+	return nil
+}
 
-		ioutil.WriteFile(file.Path, newContents, 0600)
+// write writes the file to its current path if it has one and it changed.
+func (file *YamlFile[T]) write() error {
+	if file.Path == "" {
+		return nil
 	}
 
-	// File was deleted or moved.
-	if file.Path == "" || file.Path != file.originalPath {
-		return os.Remove(file.originalPath)
+	newContents, err := file.marshal()
+	if err != nil {
+		return fmt.Errorf("couldn't marshal %s: %w", file.Path, err)
+	}
+
+	if !file.moved() && bytes.Equal(newContents, file.originalData) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+		return fmt.Errorf("couldn't create directory for %s: %w", file.Path, err)
+	}
+
+	if err := ioutil.WriteFile(file.Path, newContents, 0600); err != nil {
+		return fmt.Errorf("couldn't write %s: %w", file.Path, err)
 	}
 
 	return nil
 }
+
+// name returns the path of the file, including the index of the document if
+// the file contains several.
+func (file *YamlFile[T]) name() string {
+	path := file.Path
+	if path == "" {
+		path = file.originalPath
+	}
+	return documentName(path, file.document, file.documents)
+}
+
+// moved returns true if the file was loaded from a path it no longer has.
+func (file *YamlFile[T]) moved() bool {
+	return file.originalPath != "" && file.Path != file.originalPath
+}
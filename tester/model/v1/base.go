@@ -2,12 +2,15 @@ package v1
 
 import (
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/josephlewis42/scheme-compliance/tester/executor"
 	"github.com/josephlewis42/scheme-compliance/tester/validation"
 
 	"k8s.io/apimachinery/pkg/labels"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -79,7 +82,7 @@ func (m *Metadata) ConvertToInternal() *executor.Metadata {
 }
 
 type IdentifiableMetadata struct {
-	// Unique name for the object.
+	// Unique ID for the object, generated by tidy.
 	UUID *string `json:"uuid"`
 
 	DisplayMetadata `json:",inline"`
@@ -115,6 +118,8 @@ type DisplayMetadata struct {
 	Description string `json:"description,omitempty"`
 	// Note for the object. Won't be displayed to users.
 	Note string `json:"note,omitempty"`
+	// Annotations for tools, e.g. lint/disable. Won't be cascaded.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 func (m *DisplayMetadata) Validate(validator *validation.Validator) {
@@ -125,8 +130,42 @@ var _ validation.Validatable = (*Metadata)(nil)
 
 type Labels map[string]string
 
+// LabelValueFrom converts text into a valid label value, characters that
+// aren't allowed are replaced with "-".
+func LabelValueFrom(text string) string {
+	var out []rune
+	for _, r := range text {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			out = append(out, r)
+		default:
+			out = append(out, '-')
+		}
+	}
+
+	if len(out) > k8svalidation.LabelValueMaxLength {
+		out = out[:k8svalidation.LabelValueMaxLength]
+	}
+	return strings.Trim(string(out), "-_.")
+}
+
+// Validate checks keys are qualified names and values are valid label values.
 func (m Labels) Validate(validator *validation.Validator) {
-	// TODO: add validation
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			validator.AtKey(key).Error("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+
+		if errs := k8svalidation.IsValidLabelValue(m[key]); len(errs) > 0 {
+			validator.AtKey(key).Error("invalid value %q: %s", m[key], strings.Join(errs, "; "))
+		}
+	}
 }
 
 func (m Labels) MergeOver(over Labels) Labels {
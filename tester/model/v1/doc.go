@@ -1,3 +1,5 @@
 // Package v1 contains definitions for the schemetest/v1
 // conformance specification.
 package v1
+
+//go:generate go run ../../../internal/gendocs -output zz_generated.docs.go
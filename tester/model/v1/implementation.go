@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"net/url"
+
 	"github.com/josephlewis42/scheme-compliance/tester/executor"
 	"github.com/josephlewis42/scheme-compliance/tester/validation"
 )
@@ -12,6 +14,7 @@ const (
 type Implementation struct {
 	Base `json:",inline"`
 
+	// Variants of the implementation e.g. versions or operating modes.
 	Variants []ImplementationVariant `json:"variants"`
 }
 
@@ -31,6 +34,28 @@ func (impl *Implementation) Validate(validator *validation.Validator) {
 	})
 }
 
+// WalkLabels executes callback for each set of labels with a validator for their field.
+func (impl *Implementation) WalkLabels(validator *validation.Validator, callback func(*validation.Validator, Labels)) {
+	callback(validator.Field("metadata").Field("labels"), impl.Metadata.Labels)
+
+	for idx, variant := range impl.Variants {
+		callback(validator.Field("variants").AtIndex(idx).Field("metadata").Field("labels"), variant.Metadata.Labels)
+	}
+}
+
+// WalkTestSelectors executes callback for the test selector of each deviation
+// with a validator for the selector's field.
+func (impl *Implementation) WalkTestSelectors(validator *validation.Validator, callback func(*validation.Validator, LabelSelector)) {
+	for variantIdx, variant := range impl.Variants {
+		deviationsValidator := validator.Field("variants").AtIndex(variantIdx).Field("deviations")
+		for idx, deviation := range variant.Deviations {
+			if deviation.TestSelector != "" {
+				callback(deviationsValidator.AtIndex(idx).Field("testSelector"), deviation.TestSelector)
+			}
+		}
+	}
+}
+
 // Tidy cleans up the structure to remove validation warnings.
 func (impl *Implementation) Tidy() {
 	// no-op for implemnetations.
@@ -49,12 +74,17 @@ func (impl *Implementation) ConvertToInternal() *executor.Implementation {
 }
 
 type ImplementationVariant struct {
-	Metadata       Metadata             `json:"metadata"`
-	Runtime        ImplementationSource `json:"runtime"`
-	Specifications []string             `json:"specifications"`
+	Metadata Metadata `json:"metadata"`
+	// Where the variant runs.
+	Runtime ImplementationSource `json:"runtime"`
+	// Names of the specifications the variant targets.
+	Specifications []string `json:"specifications"`
 
-	// Command to run, $(PROGRAM) and $(PROGRAM_PATH) will be replaced
+	// Command to run, $(PROGRAM), $(PROGRAM_PATH) and $(PROGRAM_DIR) will be replaced
 	TestCommand []string `json:"testCommand"`
+
+	// Known deviations from the specifications.
+	Deviations []Deviation `json:"deviations,omitempty"`
 }
 
 var _ validation.Validatable = (*ImplementationVariant)(nil)
@@ -75,6 +105,12 @@ func (impl *ImplementationVariant) Validate(validator *validation.Validator) {
 			validator.Error("must reference at least one specification")
 		}
 	})
+
+	validator.WithField("deviations", func(validator *validation.Validator) {
+		for idx, deviation := range impl.Deviations {
+			deviation.Validate(validator.AtIndex(idx))
+		}
+	})
 }
 
 func (impl *ImplementationVariant) ConvertToInternal() *executor.ImplementationVariant {
@@ -89,6 +125,10 @@ func (impl *ImplementationVariant) ConvertToInternal() *executor.ImplementationV
 		TestCommand:       impl.TestCommand,
 	}
 
+	for _, deviation := range impl.Deviations {
+		out.Deviations = append(out.Deviations, deviation.ConvertToInternal())
+	}
+
 	switch {
 	case impl.Runtime.Local != nil:
 		out.Runtime = &executor.ImplementationVariant_Local{Local: &executor.ImplementationRuntimeLocal{}}
@@ -97,19 +137,83 @@ func (impl *ImplementationVariant) ConvertToInternal() *executor.ImplementationV
 	return out
 }
 
+// Deviation documents a known difference between an implementation and its
+// specifications. Tests covered by a deviation are reported as expected failures.
+type Deviation struct {
+	// UIDs of the tests the deviation applies to.
+	Tests []string `json:"tests,omitempty"`
+	// Label selector for the tests the deviation applies to.
+	TestSelector LabelSelector `json:"testSelector,omitempty"`
+
+	// Reason the implementation deviates, shown in reports.
+	Justification string `json:"justification"`
+	// Optional link to an upstream bug for the deviation.
+	BugURL string `json:"bugUrl,omitempty"`
+}
+
+var _ validation.Validatable = (*Deviation)(nil)
+
+func (deviation *Deviation) Validate(validator *validation.Validator) {
+	if len(deviation.Tests) == 0 && deviation.TestSelector == "" {
+		validator.Error("must set at least one of %q", []string{"tests", "testSelector"})
+	}
+
+	validator.WithField("tests", func(validator *validation.Validator) {
+		for idx, uid := range deviation.Tests {
+			validation.AssertNotBlank(validator.AtIndex(idx), uid)
+		}
+	})
+
+	if deviation.TestSelector != "" {
+		validator.WithField("testSelector", deviation.TestSelector.Validate)
+	}
+
+	validator.WithField("justification", func(validator *validation.Validator) {
+		validation.AssertNotBlank(validator, deviation.Justification)
+	})
+
+	validator.WithField("bugUrl", func(validator *validation.Validator) {
+		if deviation.BugURL == "" {
+			return
+		}
+
+		if parsed, err := url.Parse(deviation.BugURL); err != nil || !parsed.IsAbs() {
+			validator.Error("must be an absolute URL")
+		}
+	})
+}
+
+func (deviation *Deviation) ConvertToInternal() *executor.Deviation {
+	return &executor.Deviation{
+		TestUids:      deviation.Tests,
+		TestSelector:  string(deviation.TestSelector),
+		Justification: deviation.Justification,
+		BugUrl:        deviation.BugURL,
+	}
+}
+
 type ImplementationSource struct {
 	// Image      ImplementationSourceImage `json:"image,omitempty"`
 	// Dockerfile ImplementationSourceBuild `json:"dockerfile,omitempty"`
+
+	// Run tests using commands on the local machine.
 	Local *ImplementationSourceLocal `json:"local,omitempty"`
 }
 
 var _ validation.Validatable = (*ImplementationSource)(nil)
 
 func (impl *ImplementationSource) Validate(validator *validation.Validator) {
-	validation.OneOf().
-		ValidatedField("local", impl.Local != nil, impl.Local.Validate).
-		Validate(validator)
+	impl.oneOf().Validate(validator)
+}
+
+// OneOfFields returns the fields of which exactly one must be set.
+func (impl *ImplementationSource) OneOfFields() []string {
+	return impl.oneOf().Fields()
+}
 
+func (impl *ImplementationSource) oneOf() *validation.OneOfBuilder {
+	return validation.OneOf().
+		ValidatedField("local", impl.Local != nil, impl.Local.Validate)
 }
 
 type ImplementationSourceLocal struct {
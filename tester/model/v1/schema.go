@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/josephlewis42/scheme-compliance/tester/jsonschema"
+)
+
+// kinds holds the Go type of each top level kind.
+var kinds = map[string]reflect.Type{
+	KindTest:           reflect.TypeOf(Test{}),
+	KindTestSuite:      reflect.TypeOf(TestSuite{}),
+	TypeSpecification:  reflect.TypeOf(Specification{}),
+	TypeImplementation: reflect.TypeOf(Implementation{}),
+}
+
+// Kinds returns the name of every top level kind in sorted order.
+func Kinds() (out []string) {
+	for kind := range kinds {
+		out = append(out, kind)
+	}
+	sort.Strings(out)
+	return
+}
+
+// JSONSchema generates a JSON Schema for documents of the given kind.
+//
+// Expectations are restricted to the supplied assertion definitions and their
+// input schemas if any are given, otherwise any single assertion is allowed.
+func JSONSchema(kind string, definitions []TestSuiteSpecAssertionDefintion) (*jsonschema.Schema, error) {
+	kindType, ok := kinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %q, must be one of %q", kind, Kinds())
+	}
+
+	generator := &jsonschema.Generator{
+		Description: describeType,
+		Override: func(t reflect.Type) *jsonschema.Schema {
+			if t == reflect.TypeOf(TestExpectation{}) {
+				return expectationSchema(definitions)
+			}
+			return nil
+		},
+		Extend: extendSchema,
+	}
+
+	out := generator.Root(kindType)
+	out.Title = kind
+	out.Properties["apiVersion"].Const = APIVersion
+	out.Properties["kind"].Const = kind
+	out.Required = append([]string{"apiVersion", "kind", "metadata"}, out.Required...)
+
+	return out, nil
+}
+
+func describeType(t reflect.Type, field string) string {
+	if field == "" {
+		return typeDocs[t.Name()]
+	}
+	return fieldDocs[t.Name()][field]
+}
+
+func extendSchema(t reflect.Type, schema *jsonschema.Schema) {
+	switch t {
+	case reflect.TypeOf(Metadata{}):
+		name := schema.Properties["name"]
+		name.Pattern = "^" + NameMatcher + "$"
+		name.MaxLength = jsonschema.Int(63)
+		schema.Required = append(schema.Required, "name")
+
+	case reflect.TypeOf(TestCase{}):
+		schema.Required = append(schema.Required, "input")
+	}
+}
+
+func expectationSchema(definitions []TestSuiteSpecAssertionDefintion) *jsonschema.Schema {
+	out := &jsonschema.Schema{
+		Type:        "object",
+		Description: "Assertion the output must satisfy, exactly one may be set.",
+	}
+
+	if len(definitions) == 0 {
+		out.MinProperties = jsonschema.Int(1)
+		out.MaxProperties = jsonschema.Int(1)
+		return out
+	}
+
+	for _, defn := range definitions {
+		input := &jsonschema.Schema{}
+		if len(defn.InputSchema) > 0 {
+			input = jsonschema.Raw(defn.InputSchema)
+		}
+
+		out.OneOf = append(out.OneOf, &jsonschema.Schema{
+			Title:                defn.Name,
+			Properties:           map[string]*jsonschema.Schema{defn.Name: input},
+			Required:             []string{defn.Name},
+			AdditionalProperties: false,
+		})
+	}
+
+	return out
+}
@@ -17,8 +17,10 @@ const (
 type Specification struct {
 	Base `json:",inline"`
 
-	Sections              []SpecificationSection `json:"sections"`
-	ExclusionTestSelector LabelSelector          `json:"exclusionTestSelector,omitempty"`
+	// Sections that make up the specification.
+	Sections []SpecificationSection `json:"sections"`
+	// Label selector for tests that never apply to the specification.
+	ExclusionTestSelector LabelSelector `json:"exclusionTestSelector,omitempty"`
 }
 
 var _ validation.Validatable = (*Specification)(nil)
@@ -38,9 +40,29 @@ func (spec *Specification) Validate(validator *validation.Validator) {
 	})
 
 	validator.WithField("exclusionTestSelector", func(validator *validation.Validator) {
+		if spec.ExclusionTestSelector != "" {
+			spec.ExclusionTestSelector.Validate(validator)
+		}
 	})
 }
 
+// WalkLabels executes callback for each set of labels with a validator for their field.
+func (spec *Specification) WalkLabels(validator *validation.Validator, callback func(*validation.Validator, Labels)) {
+	callback(validator.Field("metadata").Field("labels"), spec.Metadata.Labels)
+
+	for idx, section := range spec.Sections {
+		section.WalkLabels(validator.Field("sections").AtIndex(idx), callback)
+	}
+}
+
+// WalkTestSelectors executes callback for the test selector of each leaf section
+// with a validator for the selector's field.
+func (spec *Specification) WalkTestSelectors(validator *validation.Validator, callback func(*validation.Validator, LabelSelector)) {
+	for idx, section := range spec.Sections {
+		section.WalkTestSelectors(validator.Field("sections").AtIndex(idx), callback)
+	}
+}
+
 // Tidy cleans up the structure to remove validation warnings.
 func (spec *Specification) Tidy() {
 	for _, section := range spec.Sections {
@@ -50,7 +72,8 @@ func (spec *Specification) Tidy() {
 
 func (spec *Specification) ConvertToInternal() *executor.Specification {
 	internal := &executor.Specification{
-		Metadata: spec.Metadata.ConvertToInternal(),
+		Metadata:              spec.Metadata.ConvertToInternal(),
+		ExclusionTestSelector: string(spec.ExclusionTestSelector),
 	}
 
 	for _, childSection := range spec.Sections {
@@ -62,10 +85,13 @@ func (spec *Specification) ConvertToInternal() *executor.Specification {
 
 type SpecificationSection struct {
 	Metadata Metadata `json:"metadata"`
-	Optional bool     `json:"optional,omitempty"`
+	// Whether compliance with the section is optional.
+	Optional bool `json:"optional,omitempty"`
 
-	TestSelector LabelSelector          `json:"testSelector"`
-	Sections     []SpecificationSection `json:"sections,omitempty"`
+	// Label selector for the tests that make up the section.
+	TestSelector LabelSelector `json:"testSelector"`
+	// Subsections that make up the section.
+	Sections []SpecificationSection `json:"sections,omitempty"`
 }
 
 var _ validation.Validatable = (*Specification)(nil)
@@ -74,15 +100,45 @@ var _ validation.Validatable = (*Specification)(nil)
 func (section *SpecificationSection) Validate(validator *validation.Validator) {
 	section.Metadata.Validate(validator.Field("metadata"))
 
-	section.TestSelector.Validate(validator.Field("testSelctor"))
+	section.TestSelector.Validate(validator.Field("testSelector"))
 
-	validation.OneOf().
+	section.oneOf().Validate(validator)
+}
+
+// OneOfFields returns the fields of which exactly one must be set.
+func (section *SpecificationSection) OneOfFields() []string {
+	return section.oneOf().Fields()
+}
+
+func (section *SpecificationSection) oneOf() *validation.OneOfBuilder {
+	return validation.OneOf().
 		ValidatedField("testSelector", section.TestSelector != "", section.TestSelector.Validate).
 		ValidatedField("sections", len(section.Sections) > 0, func(validator *validation.Validator) {
 			for idx, section := range section.Sections {
 				section.Validate(validator.AtIndex(idx))
 			}
-		}).Validate(validator)
+		})
+}
+
+// WalkLabels executes callback for each set of labels with a validator for their field.
+func (section *SpecificationSection) WalkLabels(validator *validation.Validator, callback func(*validation.Validator, Labels)) {
+	callback(validator.Field("metadata").Field("labels"), section.Metadata.Labels)
+
+	for idx, subsection := range section.Sections {
+		subsection.WalkLabels(validator.Field("sections").AtIndex(idx), callback)
+	}
+}
+
+// WalkTestSelectors executes callback for the test selector of each leaf section
+// with a validator for the selector's field.
+func (section *SpecificationSection) WalkTestSelectors(validator *validation.Validator, callback func(*validation.Validator, LabelSelector)) {
+	if section.TestSelector != "" {
+		callback(validator.Field("testSelector"), section.TestSelector)
+	}
+
+	for idx, subsection := range section.Sections {
+		subsection.WalkTestSelectors(validator.Field("sections").AtIndex(idx), callback)
+	}
 }
 
 // Tidy cleans up the structure to remove validation warnings.
@@ -102,6 +158,7 @@ func (section *SpecificationSection) ConvertToInternal() *executor.Specification
 		internal.Content = &executor.SpecificationSection_TestSummary{
 			TestSummary: &executor.SpecificationTestSummary{
 				TestSelector: string(section.TestSelector),
+				Optional:     section.Optional,
 			},
 		}
 
@@ -114,6 +171,7 @@ func (section *SpecificationSection) ConvertToInternal() *executor.Specification
 		internal.Content = &executor.SpecificationSection_SectionSummary{
 			SectionSummary: &executor.SpecificationSectionSummary{
 				Subsections: subsections,
+				Optional:    section.Optional,
 			},
 		}
 	}
@@ -3,8 +3,13 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/josephlewis42/scheme-compliance/tester/executor"
 	"github.com/josephlewis42/scheme-compliance/tester/validation"
 )
@@ -33,13 +38,35 @@ func (t *Test) WalkCases(callback func(*executor.TestCase)) {
 	t.TestContext.WalkCases(t.effectiveTemplate(), callback)
 }
 
+// WalkEffectiveCases executes callback for each child test case along with the
+// merged template of its parents.
+func (t *Test) WalkEffectiveCases(callback func(tc *TestCase, parent HydratedTestCaseTemplate)) {
+	t.TestContext.WalkEffectiveCases(t.effectiveTemplate(), callback)
+}
+
+// WalkCaseFields executes callback for each child test case with a validator
+// for the case's field and the merged template of its parents.
+func (t *Test) WalkCaseFields(validator *validation.Validator, callback func(*validation.Validator, *TestCase, HydratedTestCaseTemplate)) {
+	t.TestContext.WalkCaseFields(validator, t.effectiveTemplate(), callback)
+}
+
 func (t *Test) Validate(validator *validation.Validator) {
 	t.Base.Validate(validator)
 	validation.AssertEqual(validator.Field("kind"), KindTest, t.Base.Kind)
 
-	validator.WithField("tests", func(validator *validation.Validator) {
-		t.TestContext.ValidateEffective(validator, t.effectiveTemplate())
-	})
+	t.TestContext.ValidateEffective(validator, t.effectiveTemplate())
+}
+
+// WalkLabels executes callback for each set of labels with a validator for their field.
+func (t *Test) WalkLabels(validator *validation.Validator, callback func(*validation.Validator, Labels)) {
+	callback(validator.Field("metadata").Field("labels"), t.Metadata.Labels)
+	t.TestContext.WalkLabels(validator, callback)
+}
+
+// WalkFiles executes callback for each file of the cases defined in the test.
+// Cases generated by a matrix share the files of the matrix's case.
+func (t *Test) WalkFiles(callback func(*TestCaseFile)) {
+	t.TestContext.WalkFiles(callback)
 }
 
 // Tidy cleans up the structure to remove validation warnings.
@@ -47,10 +74,73 @@ func (t *Test) Tidy() {
 	t.TestContext.Tidy()
 }
 
+// SplitContexts moves every top level context after the first into a Test of
+// its own with the same metadata and template. The new tests are named after
+// the context's display name, or its index if it doesn't have one.
+func (t *Test) SplitContexts() (out []Test) {
+	var kept []TestContextOrCase
+	for idx, entry := range t.Tests {
+		if entry.Context == nil || !t.hasContextBefore(idx) {
+			kept = append(kept, entry)
+			continue
+		}
+
+		suffix := NameFrom(entry.Context.Template.DisplayName)
+		if suffix == "" {
+			suffix = fmt.Sprint(idx)
+		}
+
+		split := Test{
+			Base: t.Base,
+			TestContext: TestContext{
+				Template: t.Template,
+				Tests:    []TestContextOrCase{entry},
+			},
+		}
+		split.Metadata.Name = NameFrom(t.Metadata.Name + "-" + suffix)
+		split.Metadata.Labels = Labels{}.MergeOver(t.Metadata.Labels)
+		out = append(out, split)
+	}
+
+	t.Tests = kept
+	return out
+}
+
+func (t *Test) hasContextBefore(idx int) bool {
+	for _, entry := range t.Tests[:idx] {
+		if entry.Context != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NameFrom converts text into a name matching NameMatcher.
+func NameFrom(text string) string {
+	var out []rune
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			out = append(out, r)
+		case len(out) > 0 && out[len(out)-1] != '-':
+			out = append(out, '-')
+		}
+	}
+
+	if len(out) > 63 {
+		out = out[:63]
+	}
+	return strings.TrimRight(string(out), "-")
+}
+
 // TestContext holds a set of related tests and a template that can be applied to them.
 type TestContext struct {
-	Template TestCaseTemplate    `json:"template,omitempty"`
-	Tests    []TestContextOrCase `json:"tests"`
+	// Template applied to every test in the context.
+	Template TestCaseTemplate `json:"template,omitempty"`
+	// Nested contexts and cases.
+	Tests []TestContextOrCase `json:"tests,omitempty"`
+	// Cases generated from a table of parameters, after the tests.
+	Matrix *TestMatrix `json:"matrix,omitempty"`
 }
 
 // Tidy cleans up the structure to remove validation warnings.
@@ -58,14 +148,85 @@ func (t *TestContext) Tidy() {
 	for _, test := range t.Tests {
 		test.Tidy()
 	}
+	if t.Matrix != nil {
+		t.Matrix.Case.Tidy()
+	}
 }
 
 // WalkCases executes callback for each child test case that's had the template applied.
 func (t *TestContext) WalkCases(parent HydratedTestCaseTemplate, callback func(*executor.TestCase)) {
-	parent = t.Template.Hydrate(parent.WithPathSuffix("/tests"))
+	t.WalkEffectiveCases(parent, func(tc *TestCase, parent HydratedTestCaseTemplate) {
+		callback(tc.Hydrate(parent))
+	})
+}
+
+// WalkEffectiveCases executes callback for each child test case along with the
+// merged template of its parents.
+func (t *TestContext) WalkEffectiveCases(parent HydratedTestCaseTemplate, callback func(tc *TestCase, parent HydratedTestCaseTemplate)) {
+	t.WalkCaseFields(&validation.Validator{}, parent, func(_ *validation.Validator, tc *TestCase, parent HydratedTestCaseTemplate) {
+		callback(tc, parent)
+	})
+}
+
+// WalkCaseFields executes callback for each child test case with a validator
+// for the case's field and the merged template of its parents.
+func (t *TestContext) WalkCaseFields(validator *validation.Validator, parent HydratedTestCaseTemplate, callback func(*validation.Validator, *TestCase, HydratedTestCaseTemplate)) {
+	parent = t.Template.Hydrate(parent)
 
 	for idx, entry := range t.Tests {
-		entry.WalkCases(parent.WithPathSuffix(fmt.Sprintf("/%d", idx)), callback)
+		entry.WalkCaseFields(validator.Field("tests").AtIndex(idx), parent.WithPathSuffix(fmt.Sprintf("/tests/%d", idx)), callback)
+	}
+
+	if t.Matrix != nil {
+		t.Matrix.WalkCaseFields(validator.Field("matrix"), parent.WithPathSuffix("/matrix"), callback)
+	}
+}
+
+// WalkLabels executes callback for each set of labels with a validator for their field.
+func (t *TestContext) WalkLabels(validator *validation.Validator, callback func(*validation.Validator, Labels)) {
+	callback(validator.Field("template").Field("labels"), t.Template.Labels)
+
+	for idx, entry := range t.Tests {
+		entryValidator := validator.Field("tests").AtIndex(idx)
+		if entry.Case != nil {
+			callback(entryValidator.Field("case").Field("labels"), entry.Case.Labels)
+		}
+		if entry.Context != nil {
+			entry.Context.WalkLabels(entryValidator.Field("context"), callback)
+		}
+	}
+
+	if t.Matrix != nil {
+		callback(validator.Field("matrix").Field("case").Field("labels"), t.Matrix.Case.Labels)
+	}
+}
+
+// WalkFiles executes callback for each file of the cases defined in the context.
+func (t *TestContext) WalkFiles(callback func(*TestCaseFile)) {
+	for _, entry := range t.Tests {
+		if entry.Case != nil {
+			entry.Case.WalkFiles(callback)
+		}
+		if entry.Context != nil {
+			entry.Context.WalkFiles(callback)
+		}
+	}
+
+	if t.Matrix != nil {
+		t.Matrix.Case.WalkFiles(callback)
+	}
+}
+
+// ValidateAssertions checks expectations in the context only use known assertion types.
+func (t *TestContext) ValidateAssertions(validator *validation.Validator, known map[string]bool) {
+	t.Template.Expect.ValidateAssertions(validator.Field("template").Field("expect"), known)
+
+	for idx, entry := range t.Tests {
+		entry.ValidateAssertions(validator.Field("tests").AtIndex(idx), known)
+	}
+
+	if t.Matrix != nil {
+		t.Matrix.Case.Expect.ValidateAssertions(validator.Field("matrix").Field("case").Field("expect"), known)
 	}
 }
 
@@ -81,12 +242,166 @@ func (t *TestContext) ValidateEffective(validator *validation.Validator, parent
 			value.ValidateEffective(validator.AtIndex(idx), parent)
 		}
 	})
+
+	if t.Matrix != nil {
+		validator.WithField("matrix", func(validator *validation.Validator) {
+			t.Matrix.ValidateEffective(validator, parent)
+		})
+	}
+}
+
+// TestMatrix generates a case for each row of a parameter table. References
+// to parameters in the form $(name) in the case's display name, input and
+// expectation are replaced with the row's values.
+type TestMatrix struct {
+	// Case to generate for each row, its UUID is combined with the row's key
+	// to give each generated case a stable UID.
+	Case TestCase `json:"case"`
+	// Parameter values for each generated case.
+	Rows []TestMatrixRow `json:"rows"`
+}
+
+// TestMatrixRow holds the parameter values for one generated case.
+type TestMatrixRow struct {
+	// Unique key for the row within the matrix, used to derive the case's UID.
+	Key string `json:"key"`
+	// Parameter values keyed by name.
+	Values map[string]string `json:"values"`
+}
+
+var matrixParameterRegexp = regexp.MustCompile(`\$\(([A-Za-z_][A-Za-z0-9_]*)\)`)
+
+// WalkCaseFields executes callback for the case generated from each row with
+// a validator for the row's field and the merged template of its parents.
+func (m *TestMatrix) WalkCaseFields(validator *validation.Validator, parent HydratedTestCaseTemplate, callback func(*validation.Validator, *TestCase, HydratedTestCaseTemplate)) {
+	for idx, row := range m.Rows {
+		callback(validator.Field("rows").AtIndex(idx), m.Expand(row), parent.WithPathSuffix("/"+row.Key))
+	}
+}
+
+// Expand returns the case generated for the row.
+func (m *TestMatrix) Expand(row TestMatrixRow) *TestCase {
+	substitute := func(text string, escape func(string) string) string {
+		return matrixParameterRegexp.ReplaceAllStringFunc(text, func(ref string) string {
+			value, ok := row.Values[matrixParameterRegexp.FindStringSubmatch(ref)[1]]
+			if !ok {
+				return ref
+			}
+			return escape(value)
+		})
+	}
+	verbatim := func(value string) string { return value }
+
+	out := m.Case
+	out.DisplayName = substitute(out.DisplayName, verbatim)
+	out.Labels = Labels{}.MergeOver(m.Case.Labels)
+
+	if m.Case.UUID != nil {
+		uid := *m.Case.UUID + "/" + row.Key
+		if namespace, err := uuid.Parse(*m.Case.UUID); err == nil {
+			uid = uuid.NewSHA1(namespace, []byte(row.Key)).String()
+		}
+		out.UUID = &uid
+	}
+
+	if m.Case.Input != nil {
+		input := substitute(*m.Case.Input, verbatim)
+		out.Input = &input
+	}
+
+	if m.Case.Expect != nil {
+		// Values are substituted into JSON strings so they must be escaped.
+		jsonString := func(value string) string {
+			encoded, _ := json.Marshal(value)
+			return string(encoded[1 : len(encoded)-1])
+		}
+
+		expect := TestExpectation{}
+		for name, options := range *m.Case.Expect {
+			expect[name] = json.RawMessage(substitute(string(options), jsonString))
+		}
+		out.Expect = &expect
+	}
+
+	return &out
+}
+
+// parameters returns the names of the parameters the case refers to.
+func (m *TestMatrix) parameters() map[string]bool {
+	texts := []string{m.Case.DisplayName}
+	if m.Case.Input != nil {
+		texts = append(texts, *m.Case.Input)
+	}
+	if m.Case.Expect != nil {
+		for _, options := range *m.Case.Expect {
+			texts = append(texts, string(options))
+		}
+	}
+
+	out := make(map[string]bool)
+	for _, text := range texts {
+		for _, match := range matrixParameterRegexp.FindAllStringSubmatch(text, -1) {
+			out[match[1]] = true
+		}
+	}
+	return out
+}
+
+func (m *TestMatrix) ValidateEffective(validator *validation.Validator, parent HydratedTestCaseTemplate) {
+	parameters := m.parameters()
+
+	validator.WithField("rows", func(validator *validation.Validator) {
+		if len(m.Rows) == 0 {
+			validator.Error("must not be empty")
+		}
+
+		for idx, row := range m.Rows {
+			rowValidator := validator.AtIndex(idx)
+
+			rowValidator.WithField("key", func(validator *validation.Validator) {
+				validation.AssertNotBlank(validator, row.Key)
+
+				if !qualifiedNameRegexp.MatchString(row.Key) {
+					validator.Error("must match %s", NameMatcher)
+				}
+			})
+
+			rowValidator.WithField("values", func(validator *validation.Validator) {
+				for _, name := range sortedKeys(parameters) {
+					if _, ok := row.Values[name]; !ok {
+						validator.Error("missing value for parameter %q", name)
+					}
+				}
+				for _, name := range sortedKeys(row.Values) {
+					if !parameters[name] {
+						validator.AtKey(name).Warning("parameter %q isn't used by the case", name)
+					}
+				}
+			})
+
+			m.Expand(row).ValidateEffective(rowValidator, parent)
+		}
+
+		validation.AssertDistinctMapping(validator, m.Rows, func(row TestMatrixRow) string {
+			return row.Key
+		}, []string{"key"})
+	})
+}
+
+func sortedKeys[V any](m map[string]V) (out []string) {
+	for key := range m {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return
 }
 
 // TestSectionOrCase is a union of section and case only one field may be set.
 type TestContextOrCase struct {
+	// A nested set of tests.
 	Context *TestContext `json:"context,omitempty"`
-	Case    *TestCase    `json:"case,omitempty"`
+	// A single test.
+	Case *TestCase `json:"case,omitempty"`
 }
 
 // Tidy cleans up the structure to remove validation warnings.
@@ -101,36 +416,92 @@ func (t *TestContextOrCase) Tidy() {
 
 // WalkCases executes callback for each child test case that's had the template applied.
 func (t *TestContextOrCase) WalkCases(parent HydratedTestCaseTemplate, callback func(*executor.TestCase)) {
+	t.WalkEffectiveCases(parent, func(tc *TestCase, parent HydratedTestCaseTemplate) {
+		callback(tc.Hydrate(parent))
+	})
+}
+
+// WalkEffectiveCases executes callback for each child test case along with the
+// merged template of its parents.
+func (t *TestContextOrCase) WalkEffectiveCases(parent HydratedTestCaseTemplate, callback func(tc *TestCase, parent HydratedTestCaseTemplate)) {
+	t.WalkCaseFields(&validation.Validator{}, parent, func(_ *validation.Validator, tc *TestCase, parent HydratedTestCaseTemplate) {
+		callback(tc, parent)
+	})
+}
+
+// WalkCaseFields executes callback for each child test case with a validator
+// for the case's field and the merged template of its parents.
+func (t *TestContextOrCase) WalkCaseFields(validator *validation.Validator, parent HydratedTestCaseTemplate, callback func(*validation.Validator, *TestCase, HydratedTestCaseTemplate)) {
+	if t.Case != nil {
+		callback(validator.Field("case"), t.Case, parent)
+	}
+	if t.Context != nil {
+		t.Context.WalkCaseFields(validator.Field("context"), parent, callback)
+	}
+}
+
+// ValidateAssertions checks expectations of the case or context only use known assertion types.
+func (t *TestContextOrCase) ValidateAssertions(validator *validation.Validator, known map[string]bool) {
 	if t.Case != nil {
-		callback(t.Case.Hydrate(parent))
+		t.Case.Expect.ValidateAssertions(validator.Field("case").Field("expect"), known)
 	}
 	if t.Context != nil {
-		t.Context.WalkCases(parent, callback)
+		t.Context.ValidateAssertions(validator.Field("context"), known)
 	}
 }
 
 func (t *TestContextOrCase) ValidateEffective(validator *validation.Validator, parent HydratedTestCaseTemplate) {
-	validation.OneOf().
-		ValidatedField("context", t.Context != nil, func(validatior *validation.Validator) {
+	t.oneOf(parent).Validate(validator)
+}
+
+// OneOfFields returns the fields of which exactly one must be set.
+func (t *TestContextOrCase) OneOfFields() []string {
+	return t.oneOf(HydratedTestCaseTemplate{}).Fields()
+}
+
+func (t *TestContextOrCase) oneOf(parent HydratedTestCaseTemplate) *validation.OneOfBuilder {
+	return validation.OneOf().
+		ValidatedField("context", t.Context != nil, func(validator *validation.Validator) {
 			t.Context.ValidateEffective(validator, parent)
 		}).
-		ValidatedField("case", t.Case != nil, func(validatior *validation.Validator) {
+		ValidatedField("case", t.Case != nil, func(validator *validation.Validator) {
 			t.Case.ValidateEffective(validator, parent)
-		}).
-		Validate(validator)
+		})
 }
 
 type TestCaseTemplate struct {
+	// Human readable name for the tests.
 	DisplayName string `json:"displayName,omitempty"`
+	// Description for the tests. Will be shown to users.
 	Description string `json:"description,omitempty"`
 
-	Labels Labels           `json:"labels,omitempty"`
+	// Labels for the tests, merged over the parent's.
+	Labels Labels `json:"labels,omitempty"`
+	// Expectation for tests that don't set their own.
 	Expect *TestExpectation `json:"expect,omitempty"`
+	// Code added before the input of the tests, after the parent's prelude.
+	Prelude string `json:"prelude,omitempty"`
+	// Code added after the input of the tests, before the parent's epilogue.
+	Epilogue string `json:"epilogue,omitempty"`
+
+	// Number of times to retry tests that don't pass, tests that pass on retry
+	// are reported as flaky.
+	Retries *int `json:"retries,omitempty"`
+
+	// Annotations for tools, e.g. lint/disable applies to the context and
+	// everything nested in it. Won't be cascaded.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 func (template *TestCaseTemplate) Validate(validator *validation.Validator) {
 	validator.WithField("labels", template.Labels.Validate)
 
+	validator.WithField("retries", func(validator *validation.Validator) {
+		if template.Retries != nil && *template.Retries < 0 {
+			validator.Error("must not be negative")
+		}
+	})
+
 	if expect := template.Expect; expect != nil {
 		validator.WithField("expect", expect.Validate)
 	}
@@ -143,10 +514,39 @@ func (template *TestCaseTemplate) Hydrate(parent HydratedTestCaseTemplate) (hydr
 
 	hydrated.Labels = template.Labels.MergeOver(parent.Labels)
 	hydrated.Expect = coalesce(template.Expect, parent.Expect)
+	hydrated.Prelude = joinCode(parent.Prelude, template.Prelude)
+	hydrated.Epilogue = joinCode(template.Epilogue, parent.Epilogue)
+	hydrated.Retries = coalesce(template.Retries, parent.Retries)
 
 	return
 }
 
+// joinCode joins the non-empty pieces of code with newlines.
+func joinCode(pieces ...string) string {
+	var out []string
+	for _, piece := range pieces {
+		if piece = strings.TrimRight(piece, "\n"); piece != "" {
+			out = append(out, piece)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// wrapCode surrounds the input with the prelude and epilogue, the input is
+// kept as-is.
+func wrapCode(prelude, input, epilogue string) string {
+	if prelude != "" {
+		input = prelude + "\n" + input
+	}
+	if epilogue != "" {
+		if input != "" && !strings.HasSuffix(input, "\n") {
+			input += "\n"
+		}
+		input += epilogue
+	}
+	return input
+}
+
 type HydratedTestCaseTemplate struct {
 	Path string
 	TestCaseTemplate
@@ -160,9 +560,17 @@ func (template *HydratedTestCaseTemplate) WithPathSuffix(suffix string) Hydrated
 
 type TestCase struct {
 	IdentifiableMetadata `json:",inline"`
-	Input                *string          `json:"input"`
-	Expect               *TestExpectation `json:"expect,omitempty"`
-	Skip                 *string          `json:"skip,omitempty"`
+	// Program given to the implementation.
+	Input *string `json:"input"`
+	// Expectation for the output, overrides the template's.
+	Expect *TestExpectation `json:"expect,omitempty"`
+	// Reason the test is skipped, if set the test isn't run.
+	Skip *string `json:"skip,omitempty"`
+	// Files written next to the program before it's run.
+	Files []TestCaseFile `json:"files,omitempty"`
+	// Number of times to retry the test if it doesn't pass, overrides the
+	// template's.
+	Retries *int `json:"retries,omitempty"`
 }
 
 // Tidy cleans up the structure to remove validation warnings.
@@ -171,7 +579,9 @@ func (t *TestCase) Tidy() {
 }
 
 func (t *TestCase) ValidateEffective(validator *validation.Validator, parent HydratedTestCaseTemplate) {
-	effectiveExpectation := coalesce(t.Expect, parent.Expect)
+	effectiveExpectation := t.EffectiveExpect(parent)
+
+	t.IdentifiableMetadata.Validate(validator)
 
 	validator.WithField("input", func(validator *validation.Validator) {
 		if t.Input == nil {
@@ -182,10 +592,11 @@ func (t *TestCase) ValidateEffective(validator *validation.Validator, parent Hyd
 	})
 
 	validator.WithField("expect", func(validator *validation.Validator) {
-		if effectiveExpectation == nil {
-			validator.Error("must be defined")
-		} else {
+		switch {
+		case effectiveExpectation != nil:
 			effectiveExpectation.Validate(validator)
+		case !t.IsSkipped():
+			validator.Error("must be defined")
 		}
 	})
 
@@ -194,6 +605,34 @@ func (t *TestCase) ValidateEffective(validator *validation.Validator, parent Hyd
 			validator.Warning("test is skipped, reason: %q", *t.Skip)
 		}
 	})
+
+	validator.WithField("retries", func(validator *validation.Validator) {
+		if t.Retries != nil && *t.Retries < 0 {
+			validator.Error("must not be negative")
+		}
+	})
+
+	validator.WithField("files", func(validator *validation.Validator) {
+		for idx, file := range t.Files {
+			file.Validate(validator.AtIndex(idx))
+		}
+
+		validation.AssertDistinctMapping(validator, t.Files, func(file TestCaseFile) string {
+			return file.Name
+		}, []string{"name"})
+	})
+}
+
+// WalkFiles executes callback for each of the case's files.
+func (tc *TestCase) WalkFiles(callback func(*TestCaseFile)) {
+	for idx := range tc.Files {
+		callback(&tc.Files[idx])
+	}
+}
+
+// EffectiveExpect returns the case's expectation or the one inherited from the parent.
+func (tc *TestCase) EffectiveExpect(parent HydratedTestCaseTemplate) *TestExpectation {
+	return coalesce(tc.Expect, parent.Expect)
 }
 
 // IsSkipped checks whether this test case should be skipped.
@@ -217,7 +656,7 @@ func (tc *TestCase) Hydrate(parent HydratedTestCaseTemplate) *executor.TestCase
 			DescriptionMarkdown: coalesce(tc.DisplayMetadata.Description, parent.Description),
 		},
 	}
-	expect := coalesce(tc.Expect, parent.Expect)
+	expect := tc.EffectiveExpect(parent)
 
 	switch {
 	case tc.IsSkipped():
@@ -230,6 +669,12 @@ func (tc *TestCase) Hydrate(parent HydratedTestCaseTemplate) *executor.TestCase
 		// string expectation_type = 2;
 		// string expectation_options_json = 3;
 
+	case tc.Input == nil:
+		out.TestType = &executor.TestCase_Invalid{
+			Invalid: &executor.InvalidTest{
+				Message: "Missing input",
+			},
+		}
 	case expect == nil:
 		out.TestType = &executor.TestCase_Invalid{
 			Invalid: &executor.InvalidTest{
@@ -244,19 +689,79 @@ func (tc *TestCase) Hydrate(parent HydratedTestCaseTemplate) *executor.TestCase
 		}
 	default:
 		for k, v := range *expect {
-			out.TestType = &executor.TestCase_Eval{
-				Eval: &executor.EvalTest{
-					Input:                  *tc.Input,
-					ExpectationType:        k,
-					ExpectationOptionsJson: string(v),
-				},
+			eval := &executor.EvalTest{
+				Input:                  wrapCode(parent.Prelude, *tc.Input, parent.Epilogue),
+				RawInput:               *tc.Input,
+				ExpectationType:        k,
+				ExpectationOptionsJson: string(v),
+			}
+
+			if retries := coalesce(tc.Retries, parent.Retries); retries != nil {
+				retries := int32(*retries)
+				eval.Retries = &retries
+			}
+
+			for _, file := range tc.Files {
+				eval.Files = append(eval.Files, file.ConvertToInternal())
 			}
+
+			out.TestType = &executor.TestCase_Eval{Eval: eval}
 		}
 	}
 
 	return &out
 }
 
+// TestCaseFile is a file a test needs next to its program, for example a
+// library it loads. Exactly one of content or path must be set.
+type TestCaseFile struct {
+	// Slash separated path of the file relative to the program's directory.
+	Name string `json:"name"`
+	// Content of the file.
+	Content *string `json:"content,omitempty"`
+	// Path to read the content from, relative to the file the test is defined in.
+	Path string `json:"path,omitempty"`
+}
+
+func (file *TestCaseFile) Validate(validator *validation.Validator) {
+	validator.WithField("name", func(validator *validation.Validator) {
+		validation.AssertNotBlank(validator, file.Name)
+
+		switch {
+		case file.Name == "":
+			// Reported as blank.
+		case !fs.ValidPath(file.Name) || file.Name == ".":
+			validator.Error("must be a slash separated path relative to the program's directory")
+		case executor.ConflictsWithProgram(file.Name):
+			validator.Error("conflicts with the program's file")
+		}
+	})
+
+	file.oneOf().Validate(validator)
+}
+
+// OneOfFields returns the fields of which exactly one must be set.
+func (file *TestCaseFile) OneOfFields() []string {
+	return file.oneOf().Fields()
+}
+
+func (file *TestCaseFile) oneOf() *validation.OneOfBuilder {
+	return validation.OneOf().
+		Field("content", file.Content != nil).
+		Field("path", file.Path != "")
+}
+
+func (file *TestCaseFile) ConvertToInternal() *executor.TestFile {
+	out := &executor.TestFile{
+		Name: file.Name,
+		Path: file.Path,
+	}
+	if file.Content != nil {
+		out.Content = *file.Content
+	}
+	return out
+}
+
 func coalesce[T any](args ...T) (zero T) {
 	for _, arg := range args {
 		if reflect.ValueOf(arg).IsZero() {
@@ -282,3 +787,28 @@ func (t *TestExpectation) Validate(validator *validation.Validator) {
 	}
 	oneof.Validate(validator)
 }
+
+// ValidateAssertions checks the expectation only uses known assertion types.
+func (t *TestExpectation) ValidateAssertions(validator *validation.Validator, known map[string]bool) {
+	if t == nil {
+		return
+	}
+
+	var knownNames []string
+	for name := range known {
+		knownNames = append(knownNames, name)
+	}
+	sort.Strings(knownNames)
+
+	var names []string
+	for name := range *t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !known[name] {
+			validator.Field(name).Error("unknown assertion type %q, must be one of %q", name, knownNames)
+		}
+	}
+}
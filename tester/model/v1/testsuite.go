@@ -2,12 +2,12 @@ package v1
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
+	"strings"
 
 	"github.com/josephlewis42/scheme-compliance/tester/executor"
 	"github.com/josephlewis42/scheme-compliance/tester/validation"
 	"github.com/xeipuuv/gojsonschema"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -16,6 +16,7 @@ const (
 
 type TestSuite struct {
 	Base `json:",inline"`
+	// Configuration for the suite.
 	Spec TestSuiteSpec `json:"spec"`
 }
 
@@ -31,15 +32,121 @@ func (suite *TestSuite) Validate(validator *validation.Validator) {
 	validator.WithField("spec", suite.Spec.Validate)
 }
 
+// WalkLabels executes callback for each set of labels with a validator for their field.
+func (suite *TestSuite) WalkLabels(validator *validation.Validator, callback func(*validation.Validator, Labels)) {
+	callback(validator.Field("metadata").Field("labels"), suite.Metadata.Labels)
+}
+
 type TestSuiteSpec struct {
+	// Assertions tests can use in their expectations.
 	Assertions TestSuiteSpecAssertionConfig `json:"assertionConfig"`
+	// Optional lint rules to check the suite with.
+	Lint *TestSuiteSpecLintConfig `json:"lint,omitempty"`
+	// Label keys objects and selectors may use, any label is allowed if empty.
+	Labels []TestSuiteSpecLabel `json:"labels,omitempty"`
+	// Other suites to import tests and specifications from.
+	Imports []TestSuiteSpecImport `json:"imports,omitempty"`
+}
+
+// TestSuiteSpecImport imports the tests and specifications of another suite.
+// Imported tests are checked with the importing suite's assertions.
+type TestSuiteSpecImport struct {
+	// Namespace for the import, prefixed to the UIDs of its tests,
+	// specifications and their sections as <name>/<uid>.
+	Name string `json:"name"`
+	// Path to the suite directory or bundle, relative to this suite.
+	Path string `json:"path"`
+	// Label selector for the tests to import, all are imported if empty.
+	TestSelector LabelSelector `json:"testSelector,omitempty"`
+}
+
+func (imp *TestSuiteSpecImport) Validate(validator *validation.Validator) {
+	validator.WithField("name", func(validator *validation.Validator) {
+		validation.AssertNotBlank(validator, imp.Name)
+
+		if !qualifiedNameRegexp.MatchString(imp.Name) {
+			validator.Error("must match %s", NameMatcher)
+		}
+	})
+
+	validator.WithField("path", func(validator *validation.Validator) {
+		validation.AssertNotBlank(validator, imp.Path)
+	})
+
+	if imp.TestSelector != "" {
+		validator.WithField("testSelector", imp.TestSelector.Validate)
+	}
+}
+
+// TestSuiteSpecLabel declares a label key and the values it may have.
+type TestSuiteSpecLabel struct {
+	// Label key.
+	Key string `json:"key"`
+	// Meaning of the label.
+	Description string `json:"description,omitempty"`
+	// Allowed values, any value is allowed if empty.
+	Values []TestSuiteSpecLabelValue `json:"values,omitempty"`
+}
+
+// TestSuiteSpecLabelValue declares an allowed value of a label.
+type TestSuiteSpecLabelValue struct {
+	// Label value.
+	Value string `json:"value"`
+	// Meaning of the value.
+	Description string `json:"description,omitempty"`
+}
+
+func (label *TestSuiteSpecLabel) Validate(validator *validation.Validator) {
+	validator.WithField("key", func(validator *validation.Validator) {
+		if errs := k8svalidation.IsQualifiedName(label.Key); len(errs) > 0 {
+			validator.Error("invalid key %q: %s", label.Key, strings.Join(errs, "; "))
+		}
+	})
+
+	validator.WithField("values", func(validator *validation.Validator) {
+		for idx, value := range label.Values {
+			if errs := k8svalidation.IsValidLabelValue(value.Value); len(errs) > 0 {
+				validator.AtIndex(idx).Field("value").Error("invalid value %q: %s", value.Value, strings.Join(errs, "; "))
+			}
+		}
+
+		validation.AssertDistinctMapping(validator, label.Values, func(value TestSuiteSpecLabelValue) string {
+			return value.Value
+		}, []string{"value"})
+	})
+}
+
+// TestSuiteSpecLintConfig enables lint rules for the suite.
+type TestSuiteSpecLintConfig struct {
+	// Level of each enabled rule keyed by rule name: error, warning, info or off.
+	Rules map[string]string `json:"rules,omitempty"`
 }
 
 var _ validation.Validatable = (*TestSuiteSpec)(nil)
 
 func (suiteSpec *TestSuiteSpec) Validate(validator *validation.Validator) {
 
-	validator.WithField("assertions", suiteSpec.Assertions.Validate)
+	validator.WithField("assertionConfig", suiteSpec.Assertions.Validate)
+
+	validator.WithField("labels", func(validator *validation.Validator) {
+		for idx, label := range suiteSpec.Labels {
+			label.Validate(validator.AtIndex(idx))
+		}
+
+		validation.AssertDistinctMapping(validator, suiteSpec.Labels, func(label TestSuiteSpecLabel) string {
+			return label.Key
+		}, []string{"key"})
+	})
+
+	validator.WithField("imports", func(validator *validation.Validator) {
+		for idx, imp := range suiteSpec.Imports {
+			imp.Validate(validator.AtIndex(idx))
+		}
+
+		validation.AssertDistinctMapping(validator, suiteSpec.Imports, func(imp TestSuiteSpecImport) string {
+			return imp.Name
+		}, []string{"name"})
+	})
 }
 
 type TestSuiteSpecAssertionConfig struct {
@@ -54,19 +161,19 @@ func (cfg *TestSuiteSpecAssertionConfig) createEmptyRuntime() (*executor.Runtime
 }
 
 func (cfg *TestSuiteSpecAssertionConfig) CreateRuntime() (*executor.Runtime, error) {
-	runtime, err := cfg.createEmptyRuntime()
-	if err != nil {
-		return nil, err
+	return executor.NewRuntimeFromConfig(cfg.ConvertToInternal())
+}
+
+func (cfg *TestSuiteSpecAssertionConfig) ConvertToInternal() *executor.AssertionConfig {
+	out := &executor.AssertionConfig{
+		Script: cfg.Script,
 	}
 
-	var errs []error
 	for _, defn := range cfg.Definitions {
-		if err := defn.Register(runtime); err != nil {
-			errs = append(errs, fmt.Errorf("couldn't register %s: %w", defn.Name, err))
-		}
+		out.Definitions = append(out.Definitions, defn.ConvertToInternal())
 	}
 
-	return runtime, errors.Join(errs...)
+	return out
 }
 
 var _ validation.Validatable = (*TestSuiteSpecAssertionConfig)(nil)
@@ -76,7 +183,7 @@ func (cfg *TestSuiteSpecAssertionConfig) Validate(validator *validation.Validato
 	runtime, err := cfg.createEmptyRuntime()
 	validator.WithField("script", func(validator *validation.Validator) {
 		if err != nil {
-			validator.Error("invalid script: %w", err)
+			validator.Error("invalid script: %v", err)
 		}
 	})
 
@@ -97,9 +204,20 @@ func (cfg *TestSuiteSpecAssertionConfig) Validate(validator *validation.Validato
 }
 
 type TestSuiteSpecAssertionDefintion struct {
-	Name         string          `json:"name"`
-	InputSchema  json.RawMessage `json:"inputSchema"`
-	FunctionName string          `json:"functionName"`
+	// Name of the expectation type used in tests.
+	Name string `json:"name"`
+	// JSON schema the expectation's options must match.
+	InputSchema json.RawMessage `json:"inputSchema"`
+	// Function in the script that evaluates the expectation.
+	FunctionName string `json:"functionName"`
+}
+
+func (defn *TestSuiteSpecAssertionDefintion) ConvertToInternal() *executor.AssertionDefinition {
+	return &executor.AssertionDefinition{
+		Name:            defn.Name,
+		FunctionName:    defn.FunctionName,
+		InputSchemaJson: string(defn.InputSchema),
+	}
 }
 
 func (defn *TestSuiteSpecAssertionDefintion) Register(runtime *executor.Runtime) error {
@@ -115,7 +233,7 @@ func (defn *TestSuiteSpecAssertionDefintion) Validate(validator *validation.Vali
 		sl := gojsonschema.NewSchemaLoader()
 		err := sl.AddSchema(defn.FunctionName, gojsonschema.NewStringLoader(string(defn.InputSchema)))
 		if err != nil {
-			validator.Error("invalid schema: %w", err)
+			validator.Error("invalid schema: %v", err)
 		}
 	})
 
@@ -124,7 +242,7 @@ func (defn *TestSuiteSpecAssertionDefintion) Validate(validator *validation.Vali
 	})
 
 	if err := defn.Register(runtime); err != nil {
-		validator.Error("bad assertion: %w", err)
+		validator.Error("bad assertion: %v", err)
 	}
 
 }
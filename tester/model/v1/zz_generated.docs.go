@@ -0,0 +1,135 @@
+// Code generated by gendocs. DO NOT EDIT.
+
+package v1
+
+// typeDocs holds the doc comments of types keyed by type name.
+var typeDocs = map[string]string{
+	"Deviation":               "Deviation documents a known difference between an implementation and its\nspecifications. Tests covered by a deviation are reported as expected failures.",
+	"Specification":           "Specification represents a real world specifcation for example an RFC.\n\nSpecifications are made up of several sections which themselves can be sub-divided.\nEach section can be marked as optional, and may have a selector associated that\nindicates which tests are used to assert compliance.",
+	"TestCaseFile":            "TestCaseFile is a file a test needs next to its program, for example a\nlibrary it loads. Exactly one of content or path must be set.",
+	"TestContext":             "TestContext holds a set of related tests and a template that can be applied to them.",
+	"TestContextOrCase":       "TestSectionOrCase is a union of section and case only one field may be set.",
+	"TestMatrix":              "TestMatrix generates a case for each row of a parameter table. References\nto parameters in the form $(name) in the case's display name, input and\nexpectation are replaced with the row's values.",
+	"TestMatrixRow":           "TestMatrixRow holds the parameter values for one generated case.",
+	"TestSuiteSpecImport":     "TestSuiteSpecImport imports the tests and specifications of another suite.\nImported tests are checked with the importing suite's assertions.",
+	"TestSuiteSpecLabel":      "TestSuiteSpecLabel declares a label key and the values it may have.",
+	"TestSuiteSpecLabelValue": "TestSuiteSpecLabelValue declares an allowed value of a label.",
+	"TestSuiteSpecLintConfig": "TestSuiteSpecLintConfig enables lint rules for the suite.",
+}
+
+// fieldDocs holds the doc comments of struct fields keyed by type then field name.
+var fieldDocs = map[string]map[string]string{
+	"Deviation": {
+		"BugURL":        "Optional link to an upstream bug for the deviation.",
+		"Justification": "Reason the implementation deviates, shown in reports.",
+		"TestSelector":  "Label selector for the tests the deviation applies to.",
+		"Tests":         "UIDs of the tests the deviation applies to.",
+	},
+	"DisplayMetadata": {
+		"Annotations": "Annotations for tools, e.g. lint/disable. Won't be cascaded.",
+		"Description": "Description for the object. Will be shown to users.",
+		"DisplayName": "Human readable name for the object.",
+		"Labels":      "Labels for the object. Will be cascaded from the parent.",
+		"Note":        "Note for the object. Won't be displayed to users.",
+	},
+	"IdentifiableMetadata": {
+		"UUID": "Unique ID for the object, generated by tidy.",
+	},
+	"Implementation": {
+		"Variants": "Variants of the implementation e.g. versions or operating modes.",
+	},
+	"ImplementationSource": {
+		"Local": "Run tests using commands on the local machine.",
+	},
+	"ImplementationVariant": {
+		"Deviations":     "Known deviations from the specifications.",
+		"Runtime":        "Where the variant runs.",
+		"Specifications": "Names of the specifications the variant targets.",
+		"TestCommand":    "Command to run, $(PROGRAM), $(PROGRAM_PATH) and $(PROGRAM_DIR) will be replaced",
+	},
+	"Metadata": {
+		"Name": "Unique name for the object.",
+	},
+	"Specification": {
+		"ExclusionTestSelector": "Label selector for tests that never apply to the specification.",
+		"Sections":              "Sections that make up the specification.",
+	},
+	"SpecificationSection": {
+		"Optional":     "Whether compliance with the section is optional.",
+		"Sections":     "Subsections that make up the section.",
+		"TestSelector": "Label selector for the tests that make up the section.",
+	},
+	"TestCase": {
+		"Expect":  "Expectation for the output, overrides the template's.",
+		"Files":   "Files written next to the program before it's run.",
+		"Input":   "Program given to the implementation.",
+		"Retries": "Number of times to retry the test if it doesn't pass, overrides the\ntemplate's.",
+		"Skip":    "Reason the test is skipped, if set the test isn't run.",
+	},
+	"TestCaseFile": {
+		"Content": "Content of the file.",
+		"Name":    "Slash separated path of the file relative to the program's directory.",
+		"Path":    "Path to read the content from, relative to the file the test is defined in.",
+	},
+	"TestCaseTemplate": {
+		"Annotations": "Annotations for tools, e.g. lint/disable applies to the context and\neverything nested in it. Won't be cascaded.",
+		"Description": "Description for the tests. Will be shown to users.",
+		"DisplayName": "Human readable name for the tests.",
+		"Epilogue":    "Code added after the input of the tests, before the parent's epilogue.",
+		"Expect":      "Expectation for tests that don't set their own.",
+		"Labels":      "Labels for the tests, merged over the parent's.",
+		"Prelude":     "Code added before the input of the tests, after the parent's prelude.",
+		"Retries":     "Number of times to retry tests that don't pass, tests that pass on retry\nare reported as flaky.",
+	},
+	"TestContext": {
+		"Matrix":   "Cases generated from a table of parameters, after the tests.",
+		"Template": "Template applied to every test in the context.",
+		"Tests":    "Nested contexts and cases.",
+	},
+	"TestContextOrCase": {
+		"Case":    "A single test.",
+		"Context": "A nested set of tests.",
+	},
+	"TestMatrix": {
+		"Case": "Case to generate for each row, its UUID is combined with the row's key\nto give each generated case a stable UID.",
+		"Rows": "Parameter values for each generated case.",
+	},
+	"TestMatrixRow": {
+		"Key":    "Unique key for the row within the matrix, used to derive the case's UID.",
+		"Values": "Parameter values keyed by name.",
+	},
+	"TestSuite": {
+		"Spec": "Configuration for the suite.",
+	},
+	"TestSuiteSpec": {
+		"Assertions": "Assertions tests can use in their expectations.",
+		"Imports":    "Other suites to import tests and specifications from.",
+		"Labels":     "Label keys objects and selectors may use, any label is allowed if empty.",
+		"Lint":       "Optional lint rules to check the suite with.",
+	},
+	"TestSuiteSpecAssertionConfig": {
+		"Script": "Script to evaluate functions within.",
+	},
+	"TestSuiteSpecAssertionDefintion": {
+		"FunctionName": "Function in the script that evaluates the expectation.",
+		"InputSchema":  "JSON schema the expectation's options must match.",
+		"Name":         "Name of the expectation type used in tests.",
+	},
+	"TestSuiteSpecImport": {
+		"Name":         "Namespace for the import, prefixed to the UIDs of its tests,\nspecifications and their sections as <name>/<uid>.",
+		"Path":         "Path to the suite directory or bundle, relative to this suite.",
+		"TestSelector": "Label selector for the tests to import, all are imported if empty.",
+	},
+	"TestSuiteSpecLabel": {
+		"Description": "Meaning of the label.",
+		"Key":         "Label key.",
+		"Values":      "Allowed values, any value is allowed if empty.",
+	},
+	"TestSuiteSpecLabelValue": {
+		"Description": "Meaning of the value.",
+		"Value":       "Label value.",
+	},
+	"TestSuiteSpecLintConfig": {
+		"Rules": "Level of each enabled rule keyed by rule name: error, warning, info or off.",
+	},
+}
@@ -0,0 +1,3 @@
+// Package scaffold generates starter files for new suites, specifications,
+// implementations and tests.
+package scaffold
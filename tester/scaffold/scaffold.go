@@ -0,0 +1,163 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/josephlewis42/scheme-compliance/tester/model/storage"
+	v1 "github.com/josephlewis42/scheme-compliance/tester/model/v1"
+)
+
+// starterScript is the assertion script for new suites.
+const starterScript = `// Assertion functions receive a test object with the following structure:
+//
+// metadata:
+//   uid: string // UID for the test
+//   labels: map<string, string> // labels for the test
+// input: string // The test's input value
+// config: <any> // Parsed configuration matching the inputSchema.
+// output:
+//   stdout: string // stdout of the process
+//   stderr: string // stderr of the process
+//   exitCode: int  // exit code of the process
+//
+// They're expected to return an object with a single top level field depending on the outcome:
+//
+// Success:
+//   {"success":{}}
+//
+// Failure:
+//   {"failure": {"message": "some failure message"}}
+function fail(message) {
+  return {"failure": {"message": message || "failed evaluation"}};
+}
+function success(testContext) {
+  return {"success": {}};
+}
+function exact(testContext) {
+  if (testContext.config === testContext.output.stdout) {
+    return success();
+  }
+  return fail("expected: " + testContext.config + " got: " + testContext.output.stdout);
+}`
+
+// SectionLabel is the label key new tests and specification sections use to
+// select each other.
+const SectionLabel = "section"
+
+func base(kind, name string) v1.Base {
+	return v1.Base{
+		Version: v1.Version{
+			APIVersion: v1.APIVersion,
+			Kind:       kind,
+		},
+		Metadata: v1.Metadata{
+			Name: name,
+		},
+	}
+}
+
+// NewSuite creates the TestSuite file for a new suite rooted at dir.
+func NewSuite(dir, name string) *storage.YamlFile[v1.TestSuite] {
+	file := storage.NewYamlFile[v1.TestSuite](filepath.Join(dir, name+".yaml"))
+	file.Value = v1.TestSuite{
+		Base: base(v1.KindTestSuite, name),
+		Spec: v1.TestSuiteSpec{
+			Assertions: v1.TestSuiteSpecAssertionConfig{
+				Script: starterScript,
+				Definitions: []v1.TestSuiteSpecAssertionDefintion{
+					{
+						Name:         "exact",
+						FunctionName: "exact",
+						InputSchema:  json.RawMessage(`{"type":"string"}`),
+					},
+					{
+						Name:         "success",
+						FunctionName: "success",
+						InputSchema:  json.RawMessage(`{"type":"object"}`),
+					},
+				},
+			},
+		},
+	}
+
+	return file
+}
+
+// NewSpecification creates a specification in the suite with a single section
+// selecting tests labeled with the section's name.
+func NewSpecification(suite *storage.Suite, name string) *storage.YamlFile[v1.Specification] {
+	file := storage.NewYamlFile[v1.Specification](suite.SpecificationPath(name))
+	file.Value = v1.Specification{
+		Base: base(v1.TypeSpecification, name),
+		Sections: []v1.SpecificationSection{
+			{
+				Metadata: v1.Metadata{
+					Name: "example",
+					DisplayMetadata: v1.DisplayMetadata{
+						DisplayName: "Example",
+					},
+				},
+				TestSelector: v1.LabelSelector(fmt.Sprintf("%s=%s", SectionLabel, "example")),
+			},
+		},
+	}
+
+	return file
+}
+
+// NewImplementation creates an implementation in the suite with a single local
+// variant targeting the given specifications.
+func NewImplementation(suite *storage.Suite, name string, specifications []string) *storage.YamlFile[v1.Implementation] {
+	file := storage.NewYamlFile[v1.Implementation](suite.ImplementationPath(name))
+	file.Value = v1.Implementation{
+		Base: base(v1.TypeImplementation, name),
+		Variants: []v1.ImplementationVariant{
+			{
+				Metadata: v1.Metadata{
+					Name: "latest",
+				},
+				Runtime: v1.ImplementationSource{
+					Local: &v1.ImplementationSourceLocal{},
+				},
+				Specifications: specifications,
+				TestCommand:    []string{"cat", "$(PROGRAM_PATH)"},
+			},
+		},
+	}
+
+	return file
+}
+
+// NewTest creates a test in the suite with a single case using the given
+// expectation type, the case is labeled so new specifications select it.
+func NewTest(suite *storage.Suite, name, expectationType string, expectation json.RawMessage) *storage.YamlFile[v1.Test] {
+	input := "TODO"
+
+	file := storage.NewYamlFile[v1.Test](suite.TestPath(name))
+	file.Value = v1.Test{
+		Base: base(v1.KindTest, name),
+		TestContext: v1.TestContext{
+			Template: v1.TestCaseTemplate{
+				Labels: v1.Labels{SectionLabel: "example"},
+			},
+			Tests: []v1.TestContextOrCase{
+				{
+					Case: &v1.TestCase{
+						IdentifiableMetadata: v1.IdentifiableMetadata{
+							DisplayMetadata: v1.DisplayMetadata{
+								DisplayName: "example",
+							},
+						},
+						Input:  &input,
+						Expect: &v1.TestExpectation{expectationType: expectation},
+					},
+				},
+			},
+		},
+	}
+
+	file.Value.Tidy()
+	return file
+}
@@ -0,0 +1,3 @@
+// Package scoring computes compliance scores for implementations against
+// the sections of each specification they target.
+package scoring
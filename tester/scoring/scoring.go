@@ -0,0 +1,342 @@
+package scoring
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Status is the derived compliance status of a node in the specification tree.
+type Status string
+
+const (
+	// StatusFull indicates every test that was run passed.
+	StatusFull Status = "full"
+	// StatusPartial indicates some, but not all, tests passed.
+	StatusPartial Status = "partial"
+	// StatusNone indicates tests were run but none passed.
+	StatusNone Status = "none"
+	// StatusUntested indicates no tests were run.
+	StatusUntested Status = "untested"
+)
+
+// Counts holds the number of tests with each outcome.
+//
+// Tests without a stored result are counted as skipped.
+type Counts struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Errored int `json:"errored"`
+}
+
+// Total returns the number of tests counted.
+func (c Counts) Total() int {
+	return c.Passed + c.Failed + c.Skipped + c.Errored
+}
+
+// Run returns the number of tests that ran to completion or errored.
+func (c Counts) Run() int {
+	return c.Passed + c.Failed + c.Errored
+}
+
+// Percentage returns the percentage of run tests that passed, or 0 if none ran.
+func (c Counts) Percentage() float64 {
+	if c.Run() == 0 {
+		return 0
+	}
+
+	return 100 * float64(c.Passed) / float64(c.Run())
+}
+
+// Status derives the compliance status from the counts.
+func (c Counts) Status() Status {
+	switch {
+	case c.Run() == 0:
+		return StatusUntested
+	case c.Passed == c.Run():
+		return StatusFull
+	case c.Passed == 0:
+		return StatusNone
+	default:
+		return StatusPartial
+	}
+}
+
+// Score is the compliance score for a specification or one of its sections.
+type Score struct {
+	Uid         string `json:"uid"`
+	DisplayName string `json:"displayName,omitempty"`
+	Optional    bool   `json:"optional,omitempty"`
+
+	Counts
+	Percentage float64 `json:"percentage"`
+	Status     Status  `json:"status"`
+
+	Sections []*Score `json:"sections,omitempty"`
+
+	// outcomes holds the outcome of every test under this node keyed by UID
+	// so tests selected by multiple children are only counted once.
+	outcomes map[string]outcome
+}
+
+// VariantScore holds the scores of a single implementation variant against
+// each specification it targets.
+type VariantScore struct {
+	ImplementationUid string   `json:"implementationUid"`
+	VariantUid        string   `json:"variantUid"`
+	Specifications    []*Score `json:"specifications"`
+
+	Deviations []*DeviationScore `json:"deviations,omitempty"`
+}
+
+// DeviationScore lists the tests covered by one of a variant's documented deviations.
+type DeviationScore struct {
+	Justification string `json:"justification"`
+	BugURL        string `json:"bugUrl,omitempty"`
+
+	// UIDs of covered tests that failed.
+	ExpectedFailures []string `json:"expectedFailures,omitempty"`
+	// UIDs of covered tests that passed, the deviation may no longer be needed.
+	UnexpectedPasses []string `json:"unexpectedPasses,omitempty"`
+}
+
+// ScoreSuite scores every implementation variant in the suite using the given results.
+func ScoreSuite(suite executor.TestSuite, results *executor.ResultSet) ([]*VariantScore, error) {
+	tests := suite.ListTests()
+
+	specs := make(map[string]*executor.Specification)
+	for _, spec := range suite.ListSpecifications() {
+		specs[spec.GetMetadata().GetUid()] = spec
+	}
+
+	var out []*VariantScore
+	for _, impl := range suite.ListImplementations() {
+		for _, variant := range impl.GetVariants() {
+			variantScore := &VariantScore{
+				ImplementationUid: impl.GetMetadata().GetUid(),
+				VariantUid:        variant.GetMetadata().GetUid(),
+			}
+
+			variantResults := results.ForVariant(variantScore.ImplementationUid, variantScore.VariantUid)
+			for _, specUid := range variant.GetSpecificationUids() {
+				spec, ok := specs[specUid]
+				if !ok {
+					return nil, fmt.Errorf(
+						"variant %s/%s references unknown specification %q",
+						variantScore.ImplementationUid,
+						variantScore.VariantUid,
+						specUid,
+					)
+				}
+
+				score, err := ScoreSpecification(spec, tests, variantResults)
+				if err != nil {
+					return nil, err
+				}
+				variantScore.Specifications = append(variantScore.Specifications, score)
+			}
+
+			variantScore.Deviations = scoreDeviations(variant.GetDeviations(), variantResults)
+
+			out = append(out, variantScore)
+		}
+	}
+
+	return out, nil
+}
+
+func scoreDeviations(deviations []*executor.Deviation, results map[string]*executor.TestResult) (out []*DeviationScore) {
+	var uids []string
+	for uid := range results {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	for _, deviation := range deviations {
+		score := &DeviationScore{
+			Justification: deviation.GetJustification(),
+			BugURL:        deviation.GetBugUrl(),
+		}
+
+		for _, uid := range uids {
+			result := results[uid]
+			switch {
+			case proto.Equal(result.GetExpectedFailure().GetDeviation(), deviation):
+				score.ExpectedFailures = append(score.ExpectedFailures, uid)
+			case proto.Equal(result.GetUnexpectedPass().GetDeviation(), deviation):
+				score.UnexpectedPasses = append(score.UnexpectedPasses, uid)
+			}
+		}
+
+		out = append(out, score)
+	}
+
+	return
+}
+
+// ScoreSpecification scores a specification using results keyed by test UID.
+//
+// Leaf sections are scored from the tests their selector matches, parent sections
+// and the specification itself count every test beneath them once and derive their
+// status from their non-optional children. Tests matching the specification's
+// exclusion selector aren't scored.
+func ScoreSpecification(spec *executor.Specification, tests []*executor.TestCase, results map[string]*executor.TestResult) (*Score, error) {
+	out := &Score{
+		Uid:         spec.GetMetadata().GetUid(),
+		DisplayName: spec.GetMetadata().GetDisplayName(),
+	}
+
+	tests, err := spec.ApplicableTests(tests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range spec.GetSections() {
+		child, err := scoreSection(section, tests, results)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't score specification %q: %w", out.Uid, err)
+		}
+		out.Sections = append(out.Sections, child)
+	}
+
+	out.rollUp()
+	return out, nil
+}
+
+func scoreSection(section *executor.SpecificationSection, tests []*executor.TestCase, results map[string]*executor.TestResult) (*Score, error) {
+	out := &Score{
+		Uid:         section.GetMetadata().GetUid(),
+		DisplayName: section.GetMetadata().GetDisplayName(),
+	}
+
+	switch content := section.Content.(type) {
+	case *executor.SpecificationSection_SectionSummary:
+		out.Optional = content.SectionSummary.GetOptional()
+
+		for _, subsection := range content.SectionSummary.GetSubsections() {
+			child, err := scoreSection(subsection, tests, results)
+			if err != nil {
+				return nil, err
+			}
+			out.Sections = append(out.Sections, child)
+		}
+
+		out.rollUp()
+
+	case *executor.SpecificationSection_TestSummary:
+		out.Optional = content.TestSummary.GetOptional()
+
+		selector, err := labels.Parse(content.TestSummary.GetTestSelector())
+		if err != nil {
+			return nil, fmt.Errorf("section %q has invalid selector: %w", out.Uid, err)
+		}
+
+		out.outcomes = make(map[string]outcome)
+		executor.NewFilter[*executor.TestCase]().
+			WithSelector(selector).
+			ForEach(tests, func(test *executor.TestCase) {
+				uid := test.GetMetadata().GetUid()
+				out.outcomes[uid] = classify(results[uid])
+			})
+
+		out.Counts = countOutcomes(out.outcomes)
+		out.Status = out.Counts.Status()
+
+	default:
+		out.outcomes = make(map[string]outcome)
+		out.Status = StatusUntested
+	}
+
+	out.Percentage = out.Counts.Percentage()
+	return out, nil
+}
+
+// rollUp computes the counts and status of a parent from its children.
+func (s *Score) rollUp() {
+	s.outcomes = make(map[string]outcome)
+	for _, child := range s.Sections {
+		for uid, result := range child.outcomes {
+			s.outcomes[uid] = result
+		}
+	}
+
+	s.Counts = countOutcomes(s.outcomes)
+	s.Percentage = s.Counts.Percentage()
+	s.Status = rollUpStatus(s.Sections)
+}
+
+// rollUpStatus derives the status of a parent from its non-optional
+// children, or all of them if every child is optional. Untested children are
+// ignored unless none were tested.
+func rollUpStatus(children []*Score) Status {
+	var required []*Score
+	for _, child := range children {
+		if !child.Optional {
+			required = append(required, child)
+		}
+	}
+	if len(required) == 0 {
+		required = children
+	}
+
+	statuses := make(map[Status]int)
+	for _, child := range required {
+		statuses[child.Status]++
+	}
+	tested := len(required) - statuses[StatusUntested]
+
+	switch {
+	case tested == 0:
+		return StatusUntested
+	case statuses[StatusFull] == tested:
+		return StatusFull
+	case statuses[StatusNone] == tested:
+		return StatusNone
+	default:
+		return StatusPartial
+	}
+}
+
+type outcome int
+
+const (
+	outcomeSkipped outcome = iota
+	outcomePassed
+	outcomeFailed
+	outcomeErrored
+)
+
+func classify(result *executor.TestResult) outcome {
+	switch result.GetStatus().(type) {
+	case *executor.TestResult_Success_, *executor.TestResult_UnexpectedPass_:
+		return outcomePassed
+	case *executor.TestResult_Failure_, *executor.TestResult_ExpectedFailure_, *executor.TestResult_Flaky_:
+		return outcomeFailed
+	case *executor.TestResult_Error_:
+		return outcomeErrored
+	default:
+		// Skipped tests, examples and missing results don't count towards compliance.
+		return outcomeSkipped
+	}
+}
+
+func countOutcomes(outcomes map[string]outcome) (counts Counts) {
+	for _, result := range outcomes {
+		switch result {
+		case outcomePassed:
+			counts.Passed++
+		case outcomeFailed:
+			counts.Failed++
+		case outcomeErrored:
+			counts.Errored++
+		default:
+			counts.Skipped++
+		}
+	}
+
+	return
+}
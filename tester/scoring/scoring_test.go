@@ -0,0 +1,103 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+)
+
+func TestRollUpStatus(t *testing.T) {
+	child := func(status Status, optional bool) *Score {
+		return &Score{Status: status, Optional: optional}
+	}
+
+	cases := map[string]struct {
+		children []*Score
+		want     Status
+	}{
+		"no children": {
+			want: StatusUntested,
+		},
+		"all full": {
+			children: []*Score{child(StatusFull, false), child(StatusFull, false)},
+			want:     StatusFull,
+		},
+		"all untested": {
+			children: []*Score{child(StatusUntested, false), child(StatusUntested, false)},
+			want:     StatusUntested,
+		},
+		"untested children are ignored next to full ones": {
+			children: []*Score{child(StatusFull, false), child(StatusUntested, false)},
+			want:     StatusFull,
+		},
+		"untested children are ignored next to none ones": {
+			children: []*Score{child(StatusNone, false), child(StatusUntested, false)},
+			want:     StatusNone,
+		},
+		"full and none is partial": {
+			children: []*Score{child(StatusFull, false), child(StatusNone, false), child(StatusUntested, false)},
+			want:     StatusPartial,
+		},
+		"partial child is partial": {
+			children: []*Score{child(StatusFull, false), child(StatusPartial, false)},
+			want:     StatusPartial,
+		},
+		"optional children are ignored": {
+			children: []*Score{child(StatusFull, false), child(StatusNone, true)},
+			want:     StatusFull,
+		},
+		"optional children count if all are optional": {
+			children: []*Score{child(StatusFull, true), child(StatusNone, true)},
+			want:     StatusPartial,
+		},
+		"untested required children fall back to untested": {
+			children: []*Score{child(StatusUntested, false), child(StatusFull, true)},
+			want:     StatusUntested,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := rollUpStatus(tc.children); got != tc.want {
+				t.Errorf("rollUpStatus() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScoreSpecification_exclusion(t *testing.T) {
+	spec := &executor.Specification{
+		Metadata:              &executor.Metadata{Uid: "spec"},
+		ExclusionTestSelector: "broken=true",
+		Sections: []*executor.SpecificationSection{
+			{
+				Metadata: &executor.Metadata{Uid: "all"},
+				Content: &executor.SpecificationSection_TestSummary{
+					TestSummary: &executor.SpecificationTestSummary{TestSelector: "lang=scheme"},
+				},
+			},
+		},
+	}
+
+	tests := []*executor.TestCase{
+		{Metadata: &executor.Metadata{Uid: "a", Labels: map[string]string{"lang": "scheme"}}},
+		{Metadata: &executor.Metadata{Uid: "b", Labels: map[string]string{"lang": "scheme", "broken": "true"}}},
+	}
+
+	results := map[string]*executor.TestResult{
+		"a": {Status: &executor.TestResult_Success_{Success: &executor.TestResult_Success{}}},
+		"b": {Status: &executor.TestResult_Failure_{Failure: &executor.TestResult_Failure{Message: "boom"}}},
+	}
+
+	score, err := ScoreSpecification(spec, tests, results)
+	if err != nil {
+		t.Fatalf("ScoreSpecification() = %v", err)
+	}
+
+	if want := (Counts{Passed: 1}); score.Counts != want {
+		t.Errorf("ScoreSpecification() counts = %+v, want %+v", score.Counts, want)
+	}
+	if score.Status != StatusFull {
+		t.Errorf("ScoreSpecification() status = %q, want %q", score.Status, StatusFull)
+	}
+}
@@ -0,0 +1,196 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type jsonResult struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Level   string `json:"level"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Rule    string `json:"rule,omitempty"`
+}
+
+// WriteJSON writes the results as a JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	out := []jsonResult{}
+	for _, result := range results {
+		out = append(out, jsonResult{
+			File:    result.File,
+			Line:    result.Line,
+			Column:  result.Column,
+			Level:   result.Level.String(),
+			Field:   result.Field,
+			Message: result.Message,
+			Rule:    result.Rule,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// SARIF 2.1.0 types, only the fields used are defined.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF writes the results as a SARIF 2.1.0 log produced by the named tool.
+func WriteSARIF(w io.Writer, toolName string, results []Result) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: toolName}},
+		Results: []sarifResult{},
+	}
+
+	for _, result := range results {
+		out := sarifResult{
+			RuleID:  result.Rule,
+			Level:   sarifLevel(result.Level),
+			Message: sarifMessage{Text: fmt.Sprintf("%s: %s", result.Field, result.Message)},
+		}
+
+		if result.File != "" {
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(result.File)},
+				},
+			}
+			if result.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: result.Line, StartColumn: result.Column}
+			}
+			out.Locations = append(out.Locations, location)
+		}
+
+		run.Results = append(run.Results, out)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(level Level) string {
+	switch level {
+	case LevelError:
+		return "error"
+	case LevelWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// WriteGitHub writes the results as GitHub Actions workflow commands so they're
+// shown as annotations.
+func WriteGitHub(w io.Writer, results []Result) error {
+	for _, result := range results {
+		var properties []string
+		if result.File != "" {
+			properties = append(properties, "file="+escapeGitHubProperty(filepath.ToSlash(result.File)))
+		}
+		if result.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", result.Line))
+		}
+		if result.Column > 0 {
+			properties = append(properties, fmt.Sprintf("col=%d", result.Column))
+		}
+		title := result.Field
+		if result.Rule != "" {
+			title = fmt.Sprintf("%s (%s)", result.Field, result.Rule)
+		}
+		properties = append(properties, "title="+escapeGitHubProperty(title))
+
+		_, err := fmt.Fprintf(
+			w,
+			"::%s %s::%s\n",
+			githubLevel(result.Level),
+			strings.Join(properties, ","),
+			escapeGitHubData(result.Message),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func githubLevel(level Level) string {
+	switch level {
+	case LevelError:
+		return "error"
+	case LevelWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeGitHubData(value string) string {
+	return githubDataEscaper.Replace(value)
+}
+
+func escapeGitHubProperty(value string) string {
+	return githubPropertyEscaper.Replace(value)
+}
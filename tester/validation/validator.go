@@ -43,23 +43,54 @@ func (vs *ValidationSummary) Update(v *Validator) {
 	}
 }
 
+// String returns the lowercase name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelError:
+		return "error"
+	case LevelWarning:
+		return "warning"
+	case LevelInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel parses the name of a level as returned by Level.String.
+func ParseLevel(name string) (Level, error) {
+	for _, level := range []Level{LevelError, LevelWarning, LevelInfo} {
+		if level.String() == name {
+			return level, nil
+		}
+	}
+	return LevelError, fmt.Errorf("unknown level %q, must be one of: error, warning, info", name)
+}
+
 type Result struct {
 	Level   Level
 	Field   string
 	Message string
+	// Rule is the name of the optional rule that produced the result, if any.
+	Rule string
+
+	// Position of the field in the source file, set when the validated value
+	// was loaded from a file. Lines and columns start at 1, 0 means unknown.
+	File   string
+	Line   int
+	Column int
 }
 
 func (r *Result) String() string {
-	level := "UNKNOWN"
-	switch r.Level {
-	case LevelError:
-		level = "ERROR"
-	case LevelInfo:
-		level = "INFO"
-	case LevelWarning:
-		level = "WARNING"
+	message := r.Message
+	if r.Rule != "" {
+		message = fmt.Sprintf("%s (%s)", r.Message, r.Rule)
+	}
+
+	if r.Line > 0 {
+		return fmt.Sprintf("%s: %d:%d: %s: %s", strings.ToUpper(r.Level.String()), r.Line, r.Column, r.Field, message)
 	}
-	return fmt.Sprintf("%s: %s: %s", level, r.Field, r.Message)
+	return fmt.Sprintf("%s: %s: %s", strings.ToUpper(r.Level.String()), r.Field, message)
 }
 
 func (v *Validator) addResult(r Result) {
@@ -89,6 +120,16 @@ func (v *Validator) Warning(format string, a ...any) {
 
 }
 
+// Report adds a result produced by the named rule at the given level.
+func (v *Validator) Report(rule string, level Level, format string, a ...any) {
+	v.addResult(Result{
+		Level:   level,
+		Field:   v.Prefix,
+		Message: fmt.Sprintf(format, a...),
+		Rule:    rule,
+	})
+}
+
 // Info adds a message to the current validation context.
 func (v *Validator) Info(format string, a ...any) {
 	v.addResult(Result{
@@ -143,7 +184,7 @@ func AssertDistinctMapping[T any, V comparable](v *Validator, slice []T, mapper
 
 func AssertEqual[T comparable](v *Validator, want, got T) {
 	if want != got {
-		v.Error("expected field to be %q got %q", want, got)
+		v.Error("expected field to be %q got %q", fmt.Sprint(want), fmt.Sprint(got))
 	}
 }
 
@@ -205,3 +246,11 @@ func (oob *OneOfBuilder) Validate(validator *Validator) {
 	}
 
 }
+
+// Fields returns the keys of every field in the group in the order they were added.
+func (oob *OneOfBuilder) Fields() (keys []string) {
+	for _, entry := range oob.entries {
+		keys = append(keys, entry.key)
+	}
+	return
+}
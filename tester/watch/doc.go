@@ -0,0 +1,2 @@
+// Package watch detects changes to the files and hydrated tests of a suite.
+package watch
@@ -0,0 +1,162 @@
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/josephlewis42/scheme-compliance/tester/executor"
+	"google.golang.org/protobuf/proto"
+)
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Snapshot records the modification state of the files in a suite.
+type Snapshot map[string]fileState
+
+// TakeSnapshot records the state of the suite files under root, hidden
+// directories are skipped, and of the other files given. Files that don't
+// exist are left out.
+func TakeSnapshot(root string, files []string) (Snapshot, error) {
+	out := make(Snapshot)
+
+	err := filepath.Walk(root, func(path string, info fs.FileInfo, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case info.IsDir() && path != root && strings.HasPrefix(info.Name(), "."):
+			return filepath.SkipDir
+		case info.IsDir() || !isSuiteFile(info.Name()):
+			return nil
+		}
+
+		out[path] = fileState{info.ModTime(), info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out.add(files)
+
+	return out, nil
+}
+
+// add records the state of the files that aren't in the snapshot yet, files
+// that don't exist are left out.
+func (s Snapshot) add(files []string) {
+	for _, path := range files {
+		if _, ok := s[path]; ok {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			s[path] = fileState{info.ModTime(), info.Size()}
+		}
+	}
+}
+
+func isSuiteFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// Changed returns the sorted paths that were added, removed or modified
+// between the snapshot and next.
+func (s Snapshot) Changed(next Snapshot) (changed []string) {
+	for path, state := range next {
+		if previous, ok := s[path]; !ok || previous != state {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range s {
+		if _, ok := next[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	sort.Strings(changed)
+	return
+}
+
+// Poll calls onChange with the changed paths whenever the suite files under
+// root, or the other files returned by files, change until the context is
+// done. onChange is called once with no paths when polling starts. Files
+// returned by files after a call are watched from then on.
+func Poll(ctx context.Context, root string, interval time.Duration, files func() []string, onChange func(changed []string)) error {
+	previous, err := TakeSnapshot(root, files())
+	if err != nil {
+		return err
+	}
+
+	onChange(nil)
+	previous.add(files())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			next, err := TakeSnapshot(root, files())
+			if err != nil {
+				return err
+			}
+
+			if changed := previous.Changed(next); len(changed) > 0 {
+				onChange(changed)
+				next.add(files())
+			}
+			previous = next
+		}
+	}
+}
+
+// TestHashes maps hydrated test UIDs to a hash of their content.
+type TestHashes map[string]string
+
+// HashTests hashes the content of each hydrated test.
+func HashTests(tests []*executor.TestCase) (TestHashes, error) {
+	out := make(TestHashes)
+
+	for _, test := range tests {
+		bytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(test)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(bytes)
+		out[test.GetMetadata().GetUid()] = hex.EncodeToString(sum[:])
+	}
+
+	return out, nil
+}
+
+// Changed returns the UIDs of tests in next that are new or whose content
+// differs from the previous hashes.
+func (h TestHashes) Changed(next TestHashes) (changed []string) {
+	for uid, hash := range next {
+		if previous, ok := h[uid]; !ok || previous != hash {
+			changed = append(changed, uid)
+		}
+	}
+
+	sort.Strings(changed)
+	return
+}